@@ -4,6 +4,8 @@ package board
 import (
 	"bytes"
 	"errors"
+	"math/bits"
+	"sort"
 )
 
 // Field is an integer representing a field's state.
@@ -13,12 +15,15 @@ type Field int
 type Outcome int
 
 const (
-	// Rows is the number of rows on the board.
+	// Rows is the number of rows on the classic board.
 	Rows = 6
-	// Cols is the number of columns on the board.
+	// Cols is the number of columns on the classic board.
 	Cols = 7
-	// Goal is the length of a row needed to win the game.
+	// Goal is the length of a row needed to win the game on the classic board.
 	Goal = 4
+	// MaxFields is the maximum number of fields a board can have, i.e. the
+	// product of its rows and columns.
+	MaxFields = 64
 
 	// Empty represents an empty, i.e. unplayed field.
 	Empty = Field(0)
@@ -37,55 +42,268 @@ const (
 	PlayerTwoWins = Outcome(2)
 )
 
-// Board is a two-dimensional array of fields, representing the fields of a
-// game board.
-type Board [][]Field
+// Opponent returns the field value of the other player, or Empty, if the
+// field is not assigned to a player.
+func (f Field) Opponent() Field {
+	switch f {
+	case PlayerOne:
+		return PlayerTwo
+	case PlayerTwo:
+		return PlayerOne
+	}
+	return Empty
+}
+
+// Board is a bitboard representation of a game board. The fields of each
+// player are stored in a bit mask, in which the field in column c and row r
+// (counted from the bottom) is represented by the bit c*rows+r. The number of
+// fields played per column is kept along with the masks, so that neither
+// playing a move nor finding the valid moves requires scanning the board. The
+// same holds for the Zobrist hashes of the position and of its mirror image,
+// which are updated with every field played.
+type Board struct {
+	rows       int
+	cols       int
+	goal       int
+	fields     [2]uint64
+	heights    [MaxFields]uint8
+	hash       uint64
+	mirrorHash uint64
+}
 
 // NewBoard creates a new,  empty board, i.e. a board where all fields have the
-// value Empty.
+// value Empty, with the classic dimensions of Rows, Cols and Goal.
 func NewBoard() *Board {
-	board := Board(make([][]Field, Rows))
-	for r := 0; r < Rows; r++ {
-		board[r] = make([]Field, Cols)
-		for c := 0; c < Cols; c++ {
-			board[r][c] = Empty
+	return &Board{rows: Rows, cols: Cols, goal: Goal}
+}
+
+// ErrorInvalidDimensions indicates that a board cannot be created with the
+// given dimensions, because it would have no fields, more than MaxFields
+// fields, or a goal that cannot be reached in any direction.
+var ErrorInvalidDimensions = errors.New("invalid board dimensions")
+
+// NewCustomBoard creates a new, empty board with the given number of rows and
+// columns, on which a row of length goal is needed to win the game. An
+// ErrorInvalidDimensions is returned if no such board can be created.
+func NewCustomBoard(rows, cols, goal int) (*Board, error) {
+	// the dimensions are checked on their own first, so that their product
+	// cannot overflow
+	if rows < 1 || cols < 1 || rows > MaxFields || cols > MaxFields ||
+		rows*cols > MaxFields || goal < 1 || (goal > rows && goal > cols) {
+		return nil, ErrorInvalidDimensions
+	}
+	return &Board{rows: rows, cols: cols, goal: goal}, nil
+}
+
+// Rows returns the number of rows of the board.
+func (b *Board) Rows() int {
+	return b.rows
+}
+
+// Cols returns the number of columns of the board.
+func (b *Board) Cols() int {
+	return b.cols
+}
+
+// Goal returns the length of a row needed to win the game on the board.
+func (b *Board) Goal() int {
+	return b.goal
+}
+
+// ErrorInvalidFields indicates that a board cannot be created from the given
+// fields, because their dimensions don't match the board, a field has an
+// unknown value, a field is played above an empty field, player one hasn't
+// played as many or one more fields than player two, or both players
+// completed a row.
+var ErrorInvalidFields = errors.New("invalid fields")
+
+// FromFields creates a board from a two-dimensional slice of fields, indexed
+// by row and column, with the top row coming first (as in String). The board's
+// dimensions are taken from the fields, the length of the winning row is
+// Goal. An ErrorInvalidFields is returned if the fields do not describe a
+// board that can be reached by playing moves, as far as the checks listed for
+// ErrorInvalidFields tell.
+func FromFields(fields [][]Field) (*Board, error) {
+	return FromCustomFields(fields, Goal)
+}
+
+// FromCustomFields works like FromFields, but creates a board on which a row
+// of length goal is needed to win the game.
+func FromCustomFields(fields [][]Field, goal int) (*Board, error) {
+	rows := len(fields)
+	if rows == 0 {
+		return nil, ErrorInvalidFields
+	}
+	b, err := NewCustomBoard(rows, len(fields[0]), goal)
+	if err != nil {
+		return nil, err
+	}
+	for c := 0; c < b.cols; c++ {
+		for r := rows - 1; r >= 0; r-- {
+			if len(fields[r]) != b.cols {
+				return nil, ErrorInvalidFields
+			}
+			f := fields[r][c]
+			if f == Empty {
+				continue
+			}
+			if (f != PlayerOne && f != PlayerTwo) || int(b.heights[c]) != rows-1-r {
+				return nil, ErrorInvalidFields
+			}
+			b.set(rows-1-r, c, f)
 		}
 	}
-	return &board
+	// player one moves first, and the game ends with the first row completed
+	if d := bits.OnesCount64(b.fields[0]) - bits.OnesCount64(b.fields[1]); d < 0 || d > 1 {
+		return nil, ErrorInvalidFields
+	}
+	if b.hasRow(PlayerOne) && b.hasRow(PlayerTwo) {
+		return nil, ErrorInvalidFields
+	}
+	return b, nil
+}
+
+// Field returns the value of the field in the given row and column, with row
+// 0 being the top row (as in String).
+func (b *Board) Field(row, col int) Field {
+	mask := b.bit(b.rows-1-row, col)
+	if b.fields[0]&mask != 0 {
+		return PlayerOne
+	}
+	if b.fields[1]&mask != 0 {
+		return PlayerTwo
+	}
+	return Empty
+}
+
+// Fields returns the fields of the board as a two-dimensional slice, indexed
+// by row and column, with the top row coming first.
+func (b *Board) Fields() [][]Field {
+	fields := make([][]Field, b.rows)
+	for r := 0; r < b.rows; r++ {
+		fields[r] = make([]Field, b.cols)
+		for c := 0; c < b.cols; c++ {
+			fields[r][c] = b.Field(r, c)
+		}
+	}
+	return fields
+}
+
+// Plies returns the number of moves played on the board, i.e. the number of
+// fields not being Empty.
+func (b *Board) Plies() int {
+	return bits.OnesCount64(b.fields[0] | b.fields[1])
 }
 
 // Equal compares two boards and returns true if both boards have the same
 // dimensions and field values, and false otherwise.
 func (b *Board) Equal(other *Board) bool {
-	if len(*b) != len(*other) {
-		return false
+	return b.rows == other.rows && b.cols == other.cols && b.goal == other.goal &&
+		b.fields == other.fields
+}
+
+// Hash returns the 64-bit Zobrist hash of the position on the board, so that
+// boards with equal fields have the same hash, no matter in which order the
+// moves were played. The hash is maintained incrementally and costs nothing to
+// obtain.
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
+
+// CanonicalHash returns a 64-bit hash of the position on the board, which is
+// the same for the position and its left-right mirror image.
+func (b *Board) CanonicalHash() uint64 {
+	if b.mirrorHash < b.hash {
+		return b.mirrorHash
 	}
-	for r := 0; r < len(*b); r++ {
-		if len((*b)[r]) != len((*other)[r]) {
-			return false
+	return b.hash
+}
+
+// Mirror returns a new board with the position of the board mirrored
+// left-right, i.e. with the fields of the first column in the last one and
+// vice versa.
+func (b *Board) Mirror() *Board {
+	mirror := b.Copy()
+	column := uint64(1)<<uint(b.rows) - 1
+	for c := 0; c < b.cols; c++ {
+		from, to := uint(c*b.rows), uint((b.cols-1-c)*b.rows)
+		for p := range b.fields {
+			mirror.fields[p] &^= column << to
+			mirror.fields[p] |= (b.fields[p] >> from & column) << to
 		}
-		for c := 0; c < len((*b)[r]); c++ {
-			if (*b)[r][c] != (*other)[r][c] {
-				return false
-			}
+		mirror.heights[b.cols-1-c] = b.heights[c]
+	}
+	mirror.hash, mirror.mirrorHash = b.mirrorHash, b.hash
+	return mirror
+}
+
+// Canonical returns the canonical form of the position on the board, which is
+// the same for the position and its mirror image, and whether the canonical
+// form is the mirror image. A move on the board is played on the canonical
+// form as its mirrored move, if so.
+func (b *Board) Canonical() (*Board, bool) {
+	if b.mirrorHash < b.hash {
+		return b.Mirror(), true
+	}
+	return b, false
+}
+
+// zobrist contains a random key per player and field, indexed like the bits of
+// the fields masks. The hash of a position is the xor of the keys of all
+// played fields.
+var zobrist = func() (keys [2][MaxFields]uint64) {
+	// the keys are taken from a SplitMix64 generator with a fixed seed, so
+	// that hashes are stable across runs
+	for p := range keys {
+		for i := range keys[p] {
+			keys[p][i] = SplitMix64(0, p*MaxFields+i)
 		}
 	}
-	return true
+	return keys
+}()
+
+// SplitMix64 returns the n-th number, counted from zero, generated by a
+// SplitMix64 generator with the given seed. The numbers are spread evenly over
+// all 64 bits, even for similar seeds, and can be computed independently of
+// each other.
+func SplitMix64(seed uint64, n int) uint64 {
+	x := seed + uint64(n+1)*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// set assigns the topmost empty field of the given column, which is in the
+// given row (counted from the bottom), to player, and updates the hashes.
+func (b *Board) set(bottomRow, col int, player Field) {
+	b.fields[player-1] |= b.bit(bottomRow, col)
+	b.heights[col]++
+	b.hash ^= zobrist[player-1][col*b.rows+bottomRow]
+	b.mirrorHash ^= zobrist[player-1][(b.cols-1-col)*b.rows+bottomRow]
+}
+
+// bit returns the mask of the field in the given column and row, counted from
+// the bottom.
+func (b *Board) bit(bottomRow, col int) uint64 {
+	return 1 << uint(col*b.rows+bottomRow)
 }
 
 // Move represents a column to be picked by a player in the range of [0;Cols).
 type Move int
 
+// Mirror returns the move mirrored left-right on a board with the given number
+// of columns, i.e. the move played on the mirror image of the board.
+func (m Move) Mirror(cols int) Move {
+	return Move(cols-1) - m
+}
+
 // ValidMoves returns a slice of moves that can be played, i.e. columns with an
 // Empty field.
 func (b *Board) ValidMoves() []Move {
-	validMoves := make([]Move, 0)
-	for col := 0; col < Cols; col++ {
-		for row := 0; row < Rows; row++ {
-			if (*b)[row][col] == Empty {
-				validMoves = append(validMoves, Move(col))
-				break
-			}
+	validMoves := make([]Move, 0, b.cols)
+	for col := 0; col < b.cols; col++ {
+		if int(b.heights[col]) < b.rows {
+			validMoves = append(validMoves, Move(col))
 		}
 	}
 	return validMoves
@@ -103,40 +321,30 @@ var ErrorInvalidMove = errors.New("illegal move")
 // board is not modified in the process. If the move is illegal, an
 // ErrorInvalidMove is returned.
 func (b *Board) Play(move Move, player Field) (*Board, Outcome, error) {
-	validMoves := b.ValidMoves()
-	if !Contains(validMoves, move) {
+	col := int(move)
+	if col < 0 || col >= b.cols || int(b.heights[col]) >= b.rows ||
+		(player != PlayerOne && player != PlayerTwo) {
 		return nil, -1, ErrorInvalidMove
 	}
 	newBoard := b.Copy()
-	finalRow := -1
-	for row := len(*newBoard) - 1; row >= 0; row-- {
-		if (*newBoard)[row][move] == Empty {
-			(*newBoard)[row][move] = player
-			finalRow = row
-			break
-		}
-	}
-	return newBoard, newBoard.winner(finalRow, int(move)), nil
+	row := int(newBoard.heights[col])
+	newBoard.set(row, col, player)
+	return newBoard, newBoard.winner(row, col), nil
 }
 
 // Copy creates a copy B of the initial board A, so that A.Equal(B) holds true,
 // but A == B doesn't.
 func (b *Board) Copy() *Board {
-	cpy := NewBoard()
-	for r := 0; r < len(*b); r++ {
-		for c := 0; c < len((*b)[r]); c++ {
-			(*cpy)[r][c] = (*b)[r][c]
-		}
-	}
-	return cpy
+	cpy := *b
+	return &cpy
 }
 
 // String returns a string representation of the board.
 func (b *Board) String() string {
 	buf := bytes.NewBufferString("")
-	for r := 0; r < len(*b); r++ {
-		for c := 0; c < len((*b)[r]); c++ {
-			buf.WriteRune(rune((*b)[r][c] + '0'))
+	for r := 0; r < b.rows; r++ {
+		for c := 0; c < b.cols; c++ {
+			buf.WriteRune(rune(b.Field(r, c) + '0'))
 			buf.WriteRune(' ')
 		}
 		buf.WriteRune('\n')
@@ -149,28 +357,13 @@ type shift struct {
 	h int
 }
 
-type direction int
-
-const (
-	north direction = iota
-	northEast
-	east
-	southEast
-	south
-	southWest
-	west
-	northWest
-)
-
-var shifts = map[direction]shift{
-	north:     shift{-1, 0},
-	northEast: shift{-1, 1},
-	east:      shift{0, 1},
-	southEast: shift{1, 1},
-	south:     shift{1, 0},
-	southWest: shift{1, -1},
-	west:      shift{0, -1},
-	northWest: shift{-1, -1},
+// axes are the four directions along which a row can be formed, each given as
+// the shift to one side; the other side is covered by the inverted shift.
+var axes = [...]shift{
+	{1, 0},  // vertical
+	{0, 1},  // horizontal
+	{1, 1},  // upwards
+	{-1, 1}, // downwards
 }
 
 type coord struct {
@@ -178,39 +371,30 @@ type coord struct {
 	col int
 }
 
+func (s shift) invert() shift {
+	return shift{-s.v, -s.h}
+}
+
 func (c *coord) apply(s shift) {
 	c.row += s.v
 	c.col += s.h
 }
-func (c coord) inRange() bool {
-	return c.row >= 0 && c.row < Rows && c.col >= 0 && c.col < Cols
+
+func (c coord) inRange(rows, cols int) bool {
+	return c.row >= 0 && c.row < rows && c.col >= 0 && c.col < cols
 }
 
-// winner starts at the field (*b)[setRow][setCol], checks the board in all
-// directions for fields of the same player, and returns the player's Field
-// value, if found four fields in a row of that player.
+// winner starts at the field in column setCol and row setRow (counted from the
+// bottom), checks the board along all axes for fields of the same player, and
+// returns the player's Field value, if found the board's goal of fields in a
+// row of that player.
 func (b *Board) winner(setRow, setCol int) Outcome {
-	chains := make(map[direction]int)
-	playerValue := (*b)[setRow][setCol]
-	for dir, sft := range shifts {
-		f := &coord{row: setRow, col: setCol}
-		var count int
-		for count = 0; f.inRange(); f.apply(sft) {
-			if (*b)[f.row][f.col] != playerValue {
-				break
-			}
-			count++
+	playerValue := b.owner(setRow, setCol)
+	for _, sft := range axes {
+		// origin is counted once, then each side is followed
+		if 1+b.reach(setRow, setCol, sft)+b.reach(setRow, setCol, sft.invert()) >= b.goal {
+			return Outcome(playerValue)
 		}
-		chains[dir] = count
-	}
-	// origin was counted in both directions, remove one
-	vertical := chains[north] + chains[south] - 1
-	horizontal := chains[west] + chains[east] - 1
-	upwards := chains[northEast] + chains[southWest] - 1
-	downwards := chains[southEast] + chains[northWest] - 1
-	if vertical >= Goal || horizontal >= Goal ||
-		upwards >= Goal || downwards >= Goal {
-		return Outcome(playerValue)
 	}
 	if b.hasEmptyFields() {
 		return Undecided
@@ -218,17 +402,88 @@ func (b *Board) winner(setRow, setCol int) Outcome {
 	return Tie
 }
 
-func (b *Board) hasEmptyFields() bool {
-	for r := 0; r < Rows; r++ {
-		for c := 0; c < Cols; c++ {
-			if (*b)[r][c] == Empty {
-				return true
+// owner returns the player the field in the given column and row (counted from
+// the bottom) is assigned to, which must not be empty.
+func (b *Board) owner(bottomRow, col int) Field {
+	if b.fields[0]&b.bit(bottomRow, col) == 0 {
+		return PlayerTwo
+	}
+	return PlayerOne
+}
+
+// reach returns the number of fields of the same player that follow the
+// field in column col and row bottomRow (counted from the bottom) in the
+// direction of s, not counting the field itself.
+func (b *Board) reach(bottomRow, col int, s shift) int {
+	mask := b.fields[b.owner(bottomRow, col)-1]
+	count := 0
+	f := coord{row: bottomRow, col: col}
+	for f.apply(s); f.inRange(b.rows, b.cols) && mask&b.bit(f.row, f.col) != 0; f.apply(s) {
+		count++
+	}
+	return count
+}
+
+// Coord is the position of a field, with row 0 being the top row (as in
+// Field).
+type Coord struct {
+	Row int
+	Col int
+}
+
+// WinningLines returns the rows of fields completed by the last move, if it
+// was played into the column indicated by move, and won the game: for every
+// axis along which the player has at least the board's goal of fields in a
+// row, the fields of the whole row in order, from left to right, or from the
+// bottom to the top for a vertical row. If the move didn't win the game, nil
+// is returned.
+func (b *Board) WinningLines(move Move) [][]Coord {
+	col := int(move)
+	if col < 0 || col >= b.cols || b.heights[col] == 0 {
+		return nil
+	}
+	row := int(b.heights[col]) - 1
+	var lines [][]Coord
+	for _, sft := range axes {
+		// the axes lead up or to the right, so the row is followed back first
+		back, forth := b.reach(row, col, sft.invert()), b.reach(row, col, sft)
+		if 1+back+forth < b.goal {
+			continue
+		}
+		line := make([]Coord, 0, 1+back+forth)
+		f := coord{row: row - back*sft.v, col: col - back*sft.h}
+		for i := 0; i <= back+forth; i++ {
+			line = append(line, Coord{Row: b.rows - 1 - f.row, Col: f.col})
+			f.apply(sft)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// hasRow returns true if the player with the given field has the board's goal
+// of fields in a row anywhere on the board.
+func (b *Board) hasRow(player Field) bool {
+	for col := 0; col < b.cols; col++ {
+		for row := 0; row < int(b.heights[col]); row++ {
+			if b.owner(row, col) != player {
+				continue
+			}
+			// every row is found from its end, so one side suffices
+			for _, sft := range axes {
+				if 1+b.reach(row, col, sft) >= b.goal {
+					return true
+				}
 			}
 		}
 	}
 	return false
 }
 
+func (b *Board) hasEmptyFields() bool {
+	return b.Plies() < b.rows*b.cols
+}
+
 // Contains checks if move is contained in moves, returns true if so, and else
 // otherwise.
 func Contains(moves []Move, move Move) bool {
@@ -239,3 +494,24 @@ func Contains(moves []Move, move Move) bool {
 	}
 	return false
 }
+
+// CenterFirst returns a copy of moves ordered by their distance to the center
+// column of a board with cols columns, which is the order in which moves are
+// most likely to be good. Moves with equal distances keep their order, so
+// that left comes before right for moves in ascending order (as returned by
+// ValidMoves).
+func CenterFirst(moves []Move, cols int) []Move {
+	ordered := make([]Move, len(moves))
+	copy(ordered, moves)
+	distance := func(m Move) int {
+		d := 2*int(m) - (cols - 1)
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return distance(ordered[i]) < distance(ordered[j])
+	})
+	return ordered
+}
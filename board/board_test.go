@@ -2,7 +2,7 @@ package board
 
 import "testing"
 
-var emptyBoard = Board{
+var emptyBoard = [][]Field{
 	{0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0},
 	{0, 0, 0, 0, 0, 0, 0},
@@ -11,8 +11,16 @@ var emptyBoard = Board{
 	{0, 0, 0, 0, 0, 0, 0},
 }
 
+func mustFromFields(t testing.TB, fields [][]Field) *Board {
+	b, err := FromFields(fields)
+	if err != nil {
+		t.Fatalf("create board from fields %v: %v", fields, err)
+	}
+	return b
+}
+
 func TestNewBoard(t *testing.T) {
-	expected := &emptyBoard
+	expected := mustFromFields(t, emptyBoard)
 	got := NewBoard()
 	if !expected.Equal(got) {
 		t.Errorf("expected \n%v\n, got \n%v\n", expected, got)
@@ -30,19 +38,19 @@ func TestEqual(t *testing.T) {
 func TestNotEqual(t *testing.T) {
 	boardOne := NewBoard()
 	boardTwo := NewBoard()
-	(*boardOne)[0][0] = PlayerOne
-	(*boardTwo)[0][0] = PlayerTwo
+	boardOne, _, _ = boardOne.Play(0, PlayerOne)
+	boardTwo, _, _ = boardTwo.Play(0, PlayerTwo)
 	if boardOne.Equal(boardTwo) {
 		t.Error("expected board one and two to be not equal, was true")
 	}
 }
 
 var validMovesTests = []struct {
-	board      Board
+	board      [][]Field
 	validMoves []Move
 }{
 	{
-		Board{
+		[][]Field{
 			{1, 0, 0, 0, 0, 0, 2},
 			{1, 2, 0, 0, 0, 0, 1},
 			{2, 1, 1, 0, 0, 0, 2},
@@ -53,18 +61,18 @@ var validMovesTests = []struct {
 		[]Move{1, 2, 3, 4, 5},
 	},
 	{
-		Board{
-			{1, 1, 2, 2, 1, 1, 2},
+		[][]Field{
+			{2, 1, 2, 1, 2, 1, 2},
+			{2, 1, 2, 1, 2, 1, 1},
+			{2, 1, 2, 1, 2, 1, 2},
 			{1, 2, 1, 2, 1, 2, 1},
-			{2, 1, 1, 1, 2, 2, 2},
-			{1, 2, 2, 2, 1, 2, 1},
-			{2, 1, 2, 1, 1, 1, 2},
+			{1, 2, 1, 2, 1, 2, 2},
 			{1, 2, 1, 2, 1, 2, 1},
 		},
 		[]Move{},
 	},
 	{
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -79,7 +87,7 @@ var validMovesTests = []struct {
 func TestValidMoves(t *testing.T) {
 	for _, test := range validMovesTests {
 		expected := test.validMoves
-		got := test.board.ValidMoves()
+		got := mustFromFields(t, test.board).ValidMoves()
 		if !equal(got, expected) {
 			t.Errorf("expected %v and %v to be equal, was false", got, expected)
 		}
@@ -105,12 +113,12 @@ type playerMove struct {
 }
 
 var playMoveTests = []struct {
-	before      Board
+	before      [][]Field
 	playerMoves []playerMove
-	after       Board
+	after       [][]Field
 }{
 	{
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -135,7 +143,7 @@ var playMoveTests = []struct {
 			{PlayerTwo, 4, Undecided},
 			{PlayerOne, 1, PlayerOneWins},
 		},
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 1, 0, 0, 0, 0, 0},
@@ -145,7 +153,7 @@ var playMoveTests = []struct {
 		},
 	},
 	{
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -162,7 +170,7 @@ var playMoveTests = []struct {
 			{PlayerTwo, 4, Undecided},
 			{PlayerOne, 3, PlayerOneWins},
 		},
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 1, 0, 0, 0},
@@ -172,7 +180,7 @@ var playMoveTests = []struct {
 		},
 	},
 	{
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -189,7 +197,7 @@ var playMoveTests = []struct {
 			{PlayerTwo, 4, Undecided},
 			{PlayerOne, 0, PlayerOneWins},
 		},
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -199,7 +207,7 @@ var playMoveTests = []struct {
 		},
 	},
 	{
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
@@ -220,7 +228,7 @@ var playMoveTests = []struct {
 			{PlayerTwo, 0, Undecided},
 			{PlayerOne, 6, PlayerOneWins},
 		},
-		Board{
+		[][]Field{
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 0},
 			{0, 0, 0, 0, 0, 0, 1},
@@ -233,7 +241,7 @@ var playMoveTests = []struct {
 
 func TestPlayMoves(t *testing.T) {
 	for _, test := range playMoveTests {
-		board := test.before
+		board := mustFromFields(t, test.before)
 		for _, move := range test.playerMoves {
 			b, winner, err := board.Play(move.move, move.player)
 			if err != nil {
@@ -243,13 +251,451 @@ func TestPlayMoves(t *testing.T) {
 				t.Errorf("expected winner %d for board \n%v\n, got winner %d",
 					move.winner, b, winner)
 			}
-			board = *b
+			board = b
 		}
 		got := board
-		expected := &test.after
+		expected := mustFromFields(t, test.after)
 		if !got.Equal(expected) {
 			t.Errorf("applying moves %v to board \n%v\n, expected \n%v\n, got \n%v\n",
 				test.playerMoves, test.before, expected, got)
 		}
 	}
 }
+
+var invalidFieldsTests = [][][]Field{
+	{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+	},
+	{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 3, 0, 0, 0},
+	},
+	{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 2, 0, 0, 0},
+	},
+	{
+		// player two moved first
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 2, 0, 0, 0},
+	},
+	{
+		// player one moved twice in a row
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 2, 1, 1, 0},
+	},
+	{
+		// both players completed a row
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+		{2, 1, 1, 1, 1, 0, 0},
+	},
+}
+
+func TestFromFieldsInvalid(t *testing.T) {
+	for _, fields := range invalidFieldsTests {
+		if _, err := FromFields(fields); err != ErrorInvalidFields {
+			t.Errorf("expected error %v for fields %v, got %v", ErrorInvalidFields, fields, err)
+		}
+	}
+}
+
+func TestFieldsRoundTrip(t *testing.T) {
+	for _, test := range playMoveTests {
+		b := mustFromFields(t, test.after)
+		got := mustFromFields(t, b.Fields())
+		if !got.Equal(b) {
+			t.Errorf("expected \n%v\n, got \n%v\n", b, got)
+		}
+	}
+}
+
+func TestPlayFullColumn(t *testing.T) {
+	b := NewBoard()
+	for i := 0; i < Rows; i++ {
+		b, _, _ = b.Play(0, Field(i%2+1))
+	}
+	if _, _, err := b.Play(0, PlayerOne); err != ErrorInvalidMove {
+		t.Errorf("expected error %v for full column, got %v", ErrorInvalidMove, err)
+	}
+}
+
+var customDimensionsTests = []struct {
+	rows int
+	cols int
+	goal int
+	err  error
+}{
+	{Rows, Cols, Goal, nil},
+	{7, 8, 4, nil},
+	{7, 9, 5, nil},
+	{1, 64, 4, nil},
+	{0, 7, 4, ErrorInvalidDimensions},
+	{6, 0, 4, ErrorInvalidDimensions},
+	{8, 9, 4, ErrorInvalidDimensions},
+	{6, 7, 8, ErrorInvalidDimensions},
+	{6, 7, 0, ErrorInvalidDimensions},
+	{65, 1, 4, ErrorInvalidDimensions},
+	{1 << 32, 1 << 32, 2, ErrorInvalidDimensions},
+}
+
+func TestNewCustomBoard(t *testing.T) {
+	for _, test := range customDimensionsTests {
+		b, err := NewCustomBoard(test.rows, test.cols, test.goal)
+		if err != test.err {
+			t.Errorf("expected error %v for %dx%d/%d, got %v",
+				test.err, test.rows, test.cols, test.goal, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if b.Rows() != test.rows || b.Cols() != test.cols || b.Goal() != test.goal {
+			t.Errorf("expected dimensions %dx%d/%d, got %dx%d/%d", test.rows, test.cols,
+				test.goal, b.Rows(), b.Cols(), b.Goal())
+		}
+		if got := len(b.ValidMoves()); got != test.cols {
+			t.Errorf("expected %d valid moves, got %d", test.cols, got)
+		}
+	}
+}
+
+func TestCustomBoardGoal(t *testing.T) {
+	b, err := NewCustomBoard(7, 9, 5)
+	if err != nil {
+		t.Fatalf("create 7x9/5 board: %v", err)
+	}
+	moves := []Move{4, 4, 5, 5, 6, 6, 7, 7}
+	for i, move := range moves {
+		var outcome Outcome
+		b, outcome, err = b.Play(move, Field(i%2+1))
+		if err != nil {
+			t.Fatalf("play move %d on board \n%v\n: %v", move, b, err)
+		}
+		if outcome != Undecided {
+			t.Errorf("expected no winner with four in a row, got %d", outcome)
+		}
+	}
+	_, outcome, err := b.Play(8, PlayerOne)
+	if err != nil {
+		t.Fatalf("play move 8 on board \n%v\n: %v", b, err)
+	}
+	if outcome != PlayerOneWins {
+		t.Errorf("expected player one to win with five in a row, got %d", outcome)
+	}
+}
+
+func TestCustomBoardTie(t *testing.T) {
+	b, err := NewCustomBoard(1, 3, 3)
+	if err != nil {
+		t.Fatalf("create 1x3/3 board: %v", err)
+	}
+	b, _, _ = b.Play(0, PlayerOne)
+	b, _, _ = b.Play(2, PlayerTwo)
+	if got := b.ValidMoves(); !equal(got, []Move{1}) {
+		t.Errorf("expected valid moves [1], got %v", got)
+	}
+	_, outcome, _ := b.Play(1, PlayerOne)
+	if outcome != Tie {
+		t.Errorf("expected tie on full board, got %d", outcome)
+	}
+}
+
+// playAll plays the moves alternately for both players, starting with player
+// one, on an empty board.
+func playAll(t *testing.T, moves ...Move) *Board {
+	b := NewBoard()
+	for i, move := range moves {
+		var err error
+		if b, _, err = b.Play(move, Field(i%2+1)); err != nil {
+			t.Fatalf("play moves %v: %v", moves, err)
+		}
+	}
+	return b
+}
+
+var transpositionTests = []struct {
+	one []Move
+	two []Move
+}{
+	{[]Move{3, 2, 4, 2}, []Move{4, 2, 3, 2}},
+	{[]Move{0, 1, 2, 3, 4, 5}, []Move{4, 3, 2, 5, 0, 1}},
+	{[]Move{3, 3, 3, 4, 2, 4}, []Move{2, 4, 3, 3, 3, 4}},
+}
+
+func TestHashTranspositions(t *testing.T) {
+	for _, test := range transpositionTests {
+		one, two := playAll(t, test.one...), playAll(t, test.two...)
+		if !one.Equal(two) {
+			t.Fatalf("expected moves %v and %v to reach the same position", test.one, test.two)
+		}
+		if one.Hash() != two.Hash() {
+			t.Errorf("expected same hash for moves %v and %v, got %x and %x",
+				test.one, test.two, one.Hash(), two.Hash())
+		}
+		if from := mustFromFields(t, one.Fields()); from.Hash() != one.Hash() {
+			t.Errorf("expected hash %x for board created from fields, got %x",
+				one.Hash(), from.Hash())
+		}
+	}
+}
+
+func TestHashDistinguishesPositions(t *testing.T) {
+	seen := map[uint64][]Move{NewBoard().Hash(): nil}
+	for _, moves := range [][]Move{{3}, {3, 3}, {3, 4}, {4, 3}, {0, 6}, {6, 0}} {
+		hash := playAll(t, moves...).Hash()
+		if other, ok := seen[hash]; ok {
+			t.Errorf("expected different hashes for moves %v and %v", moves, other)
+		}
+		seen[hash] = moves
+	}
+}
+
+func TestCanonicalHash(t *testing.T) {
+	one, two := playAll(t, 0, 1, 1), playAll(t, 6, 5, 5)
+	if one.Hash() == two.Hash() {
+		t.Errorf("expected different hashes for mirrored positions")
+	}
+	if one.CanonicalHash() != two.CanonicalHash() {
+		t.Errorf("expected same canonical hash for mirrored positions, got %x and %x",
+			one.CanonicalHash(), two.CanonicalHash())
+	}
+	if other := playAll(t, 0, 1, 2); other.CanonicalHash() == one.CanonicalHash() {
+		t.Errorf("expected different canonical hashes for different positions")
+	}
+}
+
+func TestMirror(t *testing.T) {
+	moves := []Move{0, 1, 1, 3, 2, 6, 6}
+	one := playAll(t, moves...)
+	mirrored := make([]Move, len(moves))
+	for i, move := range moves {
+		mirrored[i] = move.Mirror(Cols)
+	}
+	two := playAll(t, mirrored...)
+	if got := one.Mirror(); !got.Equal(two) {
+		t.Errorf("expected mirror image \n%v\n of \n%v\n, got \n%v\n", two, one, got)
+	}
+	if got := one.Mirror(); got.Hash() != two.Hash() || got.CanonicalHash() != one.CanonicalHash() {
+		t.Errorf("expected hashes of mirror image to match the played position")
+	}
+	if got := one.Mirror().Mirror(); !got.Equal(one) {
+		t.Errorf("expected mirrored mirror image \n%v\n, got \n%v\n", one, got)
+	}
+	if got := two.ValidMoves(); !equal(got, one.Mirror().ValidMoves()) {
+		t.Errorf("expected valid moves %v of mirror image, got %v", got,
+			one.Mirror().ValidMoves())
+	}
+}
+
+func TestMirrorCustomBoard(t *testing.T) {
+	b, err := NewCustomBoard(3, 4, 3)
+	if err != nil {
+		t.Fatalf("create 3x4/3 board: %v", err)
+	}
+	b, _, _ = b.Play(0, PlayerOne)
+	b, _, _ = b.Play(0, PlayerTwo)
+	b, _, _ = b.Play(2, PlayerOne)
+	expected := [][]Field{
+		{0, 0, 0, 0},
+		{0, 0, 0, 2},
+		{0, 1, 0, 1},
+	}
+	if got := b.Mirror().Fields(); !mustFromCustomFields(t, got, 3).Equal(
+		mustFromCustomFields(t, expected, 3)) {
+		t.Errorf("expected mirror image %v, got %v", expected, got)
+	}
+	if got := Move(0).Mirror(4); got != 3 {
+		t.Errorf("expected mirrored move 3, got %d", got)
+	}
+}
+
+func mustFromCustomFields(t testing.TB, fields [][]Field, goal int) *Board {
+	b, err := FromCustomFields(fields, goal)
+	if err != nil {
+		t.Fatalf("create board from fields %v: %v", fields, err)
+	}
+	return b
+}
+
+func TestCanonical(t *testing.T) {
+	one, two := playAll(t, 0, 1, 1), playAll(t, 6, 5, 5)
+	c1, m1 := one.Canonical()
+	c2, m2 := two.Canonical()
+	if !c1.Equal(c2) {
+		t.Errorf("expected same canonical form for mirrored positions, got \n%v\n and \n%v\n",
+			c1, c2)
+	}
+	if m1 == m2 {
+		t.Errorf("expected exactly one position to be mirrored, got %t and %t", m1, m2)
+	}
+	if c1.Hash() != one.CanonicalHash() {
+		t.Errorf("expected canonical form to have the canonical hash %x, got %x",
+			one.CanonicalHash(), c1.Hash())
+	}
+	symmetric := playAll(t, 3, 3, 2, 2, 4, 4)
+	if got, mirrored := symmetric.Canonical(); mirrored || !got.Equal(symmetric) {
+		t.Errorf("expected symmetric position to be its own canonical form")
+	}
+}
+
+var winningLinesTests = []struct {
+	fields [][]Field
+	move   Move
+	lines  [][]Coord
+}{
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 2, 2, 2, 0},
+			{0, 0, 1, 1, 1, 1, 0},
+		},
+		move:  2,
+		lines: [][]Coord{{{5, 2}, {5, 3}, {5, 4}, {5, 5}}},
+	},
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{1, 1, 1, 1, 0, 0, 0},
+			{2, 2, 2, 1, 0, 0, 0},
+			{1, 2, 2, 1, 2, 0, 0},
+			{2, 1, 1, 1, 2, 2, 2},
+		},
+		move: 3,
+		lines: [][]Coord{
+			{{5, 3}, {4, 3}, {3, 3}, {2, 3}},
+			{{2, 0}, {2, 1}, {2, 2}, {2, 3}},
+		},
+	},
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 2},
+			{0, 0, 0, 0, 0, 2, 1},
+			{0, 0, 0, 0, 2, 1, 1},
+			{0, 0, 0, 2, 1, 1, 2},
+		},
+		move:  6,
+		lines: [][]Coord{{{5, 3}, {4, 4}, {3, 5}, {2, 6}}},
+	},
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0, 0},
+			{0, 0, 2, 1, 0, 0, 0},
+			{0, 2, 2, 1, 0, 0, 0},
+		},
+		move: 3,
+		lines: [][]Coord{
+			{{5, 3}, {4, 3}, {3, 3}, {2, 3}},
+		},
+	},
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 2, 0, 1, 0, 0, 0},
+			{0, 2, 2, 1, 0, 2, 0},
+			{2, 1, 1, 1, 1, 1, 2},
+		},
+		move: 4,
+		lines: [][]Coord{
+			{{5, 1}, {5, 2}, {5, 3}, {5, 4}, {5, 5}},
+		},
+	},
+	{
+		fields: [][]Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0, 0},
+			{1, 2, 2, 1, 2, 2, 0},
+			{1, 2, 1, 1, 1, 2, 0},
+		},
+		move:  3,
+		lines: nil,
+	},
+}
+
+func TestWinningLines(t *testing.T) {
+	for _, test := range winningLinesTests {
+		b := mustFromFields(t, test.fields)
+		got := b.WinningLines(test.move)
+		if len(got) != len(test.lines) {
+			t.Errorf("expected winning lines %v for move %d on board \n%v\n, got %v",
+				test.lines, test.move, b, got)
+			continue
+		}
+		for i := range got {
+			if !equalCoords(got[i], test.lines[i]) {
+				t.Errorf("expected winning lines %v for move %d on board \n%v\n, got %v",
+					test.lines, test.move, b, got)
+				break
+			}
+		}
+	}
+}
+
+func equalCoords(one, two []Coord) bool {
+	if len(one) != len(two) {
+		return false
+	}
+	for i := range one {
+		if one[i] != two[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCenterFirst(t *testing.T) {
+	moves := []Move{0, 1, 2, 3, 4, 5, 6}
+	if got, expected := CenterFirst(moves, Cols), []Move{3, 2, 4, 1, 5, 0, 6}; !equal(got, expected) {
+		t.Errorf("expected moves %v, got %v", expected, got)
+	}
+	if got, expected := CenterFirst([]Move{0, 2, 3, 5}, 6), []Move{2, 3, 0, 5}; !equal(got, expected) {
+		t.Errorf("expected moves %v on even board, got %v", expected, got)
+	}
+	if !equal(moves, []Move{0, 1, 2, 3, 4, 5, 6}) {
+		t.Errorf("expected moves to be left unchanged, got %v", moves)
+	}
+}
+
+func TestSplitMix64(t *testing.T) {
+	// reference output of a SplitMix64 generator seeded with zero
+	if got := SplitMix64(0, 0); got != 0xe220a8397b1dcdaf {
+		t.Errorf("expected first number 0xe220a8397b1dcdaf, got %#x", got)
+	}
+	if SplitMix64(1, 0) == SplitMix64(0, 0) || SplitMix64(0, 1) == SplitMix64(0, 0) {
+		t.Errorf("expected different numbers for different seeds and indices")
+	}
+}
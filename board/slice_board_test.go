@@ -0,0 +1,207 @@
+package board
+
+import "testing"
+
+// sliceBoard is the former slice-of-slices board representation, kept as a
+// reference to compare the bitboard with.
+type sliceBoard [][]Field
+
+func newSliceBoard() *sliceBoard {
+	board := sliceBoard(make([][]Field, Rows))
+	for r := 0; r < Rows; r++ {
+		board[r] = make([]Field, Cols)
+	}
+	return &board
+}
+
+func (b *sliceBoard) validMoves() []Move {
+	validMoves := make([]Move, 0)
+	for col := 0; col < Cols; col++ {
+		for row := 0; row < Rows; row++ {
+			if (*b)[row][col] == Empty {
+				validMoves = append(validMoves, Move(col))
+				break
+			}
+		}
+	}
+	return validMoves
+}
+
+func (b *sliceBoard) copy() *sliceBoard {
+	cpy := newSliceBoard()
+	for r := 0; r < len(*b); r++ {
+		for c := 0; c < len((*b)[r]); c++ {
+			(*cpy)[r][c] = (*b)[r][c]
+		}
+	}
+	return cpy
+}
+
+func (b *sliceBoard) play(move Move, player Field) (*sliceBoard, Outcome, error) {
+	if !Contains(b.validMoves(), move) {
+		return nil, -1, ErrorInvalidMove
+	}
+	newBoard := b.copy()
+	finalRow := -1
+	for row := len(*newBoard) - 1; row >= 0; row-- {
+		if (*newBoard)[row][move] == Empty {
+			(*newBoard)[row][move] = player
+			finalRow = row
+			break
+		}
+	}
+	return newBoard, newBoard.winner(finalRow, int(move)), nil
+}
+
+type direction int
+
+const (
+	north direction = iota
+	northEast
+	east
+	southEast
+	south
+	southWest
+	west
+	northWest
+)
+
+// shifts are the directions the former board checked for a winner.
+var shifts = map[direction]shift{
+	north:     {-1, 0},
+	northEast: {-1, 1},
+	east:      {0, 1},
+	southEast: {1, 1},
+	south:     {1, 0},
+	southWest: {1, -1},
+	west:      {0, -1},
+	northWest: {-1, -1},
+}
+
+// winner starts at the field (*b)[setRow][setCol], checks the board in all
+// directions for fields of the same player, and returns the player's Field
+// value, if found four fields in a row of that player.
+func (b *sliceBoard) winner(setRow, setCol int) Outcome {
+	chains := make(map[direction]int)
+	playerValue := (*b)[setRow][setCol]
+	for dir, sft := range shifts {
+		f := &coord{row: setRow, col: setCol}
+		var count int
+		for count = 0; f.inRange(Rows, Cols); f.apply(sft) {
+			if (*b)[f.row][f.col] != playerValue {
+				break
+			}
+			count++
+		}
+		chains[dir] = count
+	}
+	// origin was counted in both directions, remove one
+	vertical := chains[north] + chains[south] - 1
+	horizontal := chains[west] + chains[east] - 1
+	upwards := chains[northEast] + chains[southWest] - 1
+	downwards := chains[southEast] + chains[northWest] - 1
+	if vertical >= Goal || horizontal >= Goal ||
+		upwards >= Goal || downwards >= Goal {
+		return Outcome(playerValue)
+	}
+	if b.hasEmptyFields() {
+		return Undecided
+	}
+	return Tie
+}
+
+func (b *sliceBoard) hasEmptyFields() bool {
+	for r := 0; r < Rows; r++ {
+		for c := 0; c < Cols; c++ {
+			if (*b)[r][c] == Empty {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestSliceBoardAgreement(t *testing.T) {
+	for _, test := range playMoveTests {
+		b := NewBoard()
+		s := newSliceBoard()
+		for _, move := range test.playerMoves {
+			var bo, so Outcome
+			b, bo, _ = b.Play(move.move, move.player)
+			s, so, _ = s.play(move.move, move.player)
+			if bo != so {
+				t.Errorf("outcome %d of bitboard differs from %d of slice board", bo, so)
+			}
+		}
+		got := mustFromFields(t, *s)
+		if !got.Equal(b) {
+			t.Errorf("expected \n%v\n, got \n%v\n", b, got)
+		}
+	}
+}
+
+// benchmarkMoves fills the board column by column without any player winning,
+// so that the game ends in a tie with the last move.
+var benchmarkMoves = []Move{0, 1, 0, 1, 0, 1, 1, 0, 1, 0, 1, 0,
+	2, 3, 2, 3, 2, 3, 3, 2, 3, 2, 3, 2,
+	4, 5, 4, 5, 4, 5, 5, 4, 5, 4, 5, 4,
+	6, 6, 6, 6, 6, 6}
+
+// Results of the benchmarks are assigned to these sinks, so that the compiler
+// cannot drop the benchmarked calls.
+var (
+	boardSink      *Board
+	sliceBoardSink *sliceBoard
+	movesSink      []Move
+	outcomeSink    Outcome
+)
+
+func BenchmarkPlay(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		brd := NewBoard()
+		var outcome Outcome
+		for j, move := range benchmarkMoves {
+			brd, outcome, _ = brd.Play(move, Field(j%2+1))
+		}
+		boardSink, outcomeSink = brd, outcome
+	}
+}
+
+func BenchmarkSliceBoardPlay(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		brd := newSliceBoard()
+		var outcome Outcome
+		for j, move := range benchmarkMoves {
+			brd, outcome, _ = brd.play(move, Field(j%2+1))
+		}
+		sliceBoardSink, outcomeSink = brd, outcome
+	}
+}
+
+func BenchmarkValidMoves(b *testing.B) {
+	brd := NewBoard()
+	for i := 0; i < b.N; i++ {
+		movesSink = brd.ValidMoves()
+	}
+}
+
+func BenchmarkSliceBoardValidMoves(b *testing.B) {
+	brd := newSliceBoard()
+	for i := 0; i < b.N; i++ {
+		movesSink = brd.validMoves()
+	}
+}
+
+func BenchmarkCopy(b *testing.B) {
+	brd := NewBoard()
+	for i := 0; i < b.N; i++ {
+		boardSink = brd.Copy()
+	}
+}
+
+func BenchmarkSliceBoardCopy(b *testing.B) {
+	brd := newSliceBoard()
+	for i := 0; i < b.N; i++ {
+		sliceBoardSink = brd.copy()
+	}
+}
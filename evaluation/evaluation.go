@@ -0,0 +1,113 @@
+// Package evaluation scores undecided positions heuristically, so that
+// search-based players can compare positions beyond their search horizon.
+package evaluation
+
+import "4iar/board"
+
+// Weights are the scores handed out for the features of a position.
+type Weights struct {
+	// Two is the score of a window with two fields of a player, the other
+	// fields of the window being empty.
+	Two int
+	// Three is the score of a window with one field less than the goal of a
+	// player, the remaining field being empty.
+	Three int
+	// Center is the score of each field of a player in the center column(s).
+	Center int
+	// Threat is the additional score of a Three whose empty field is on a row
+	// favouring the player: odd rows (counted from the bottom, starting at
+	// one) for player one, even rows for player two.
+	Threat int
+}
+
+// DefaultWeights are the weights used by Score.
+var DefaultWeights = Weights{
+	Two:    2,
+	Three:  5,
+	Center: 3,
+	Threat: 4,
+}
+
+// directions are the shifts (in rows and columns) along which windows are
+// formed, with row 0 being the top row.
+var directions = [...][2]int{
+	{0, 1},  // horizontal
+	{1, 0},  // vertical
+	{1, 1},  // downwards
+	{-1, 1}, // upwards
+}
+
+// Score scores board b from the perspective of the player with the given field
+// using DefaultWeights.
+func Score(b *board.Board, field board.Field) int {
+	return DefaultWeights.Score(b, field)
+}
+
+// Score scores board b from the perspective of the player with the given
+// field: the features of the player's fields count positively, those of the
+// opponent's fields negatively. Every window of the board's goal length is
+// considered, in which only one of the players has fields.
+func (w Weights) Score(b *board.Board, field board.Field) int {
+	rows, cols, goal := b.Rows(), b.Cols(), b.Goal()
+	score := 0
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			for _, d := range directions {
+				endRow, endCol := r+d[0]*(goal-1), c+d[1]*(goal-1)
+				if endRow < 0 || endRow >= rows || endCol >= cols {
+					continue
+				}
+				score += w.window(b, field, r, c, d)
+			}
+		}
+	}
+	for c := (cols - 1) / 2; c <= cols/2; c++ {
+		for r := 0; r < rows; r++ {
+			switch b.Field(r, c) {
+			case field:
+				score += w.Center
+			case field.Opponent():
+				score -= w.Center
+			}
+		}
+	}
+	return score
+}
+
+// window scores the window starting in row r and column c, extending in
+// direction d, from the perspective of the player with the given field.
+func (w Weights) window(b *board.Board, field board.Field, r, c int, d [2]int) int {
+	var counts [3]int
+	emptyRow := -1
+	for i := 0; i < b.Goal(); i++ {
+		f := b.Field(r+i*d[0], c+i*d[1])
+		counts[f]++
+		if f == board.Empty {
+			emptyRow = r + i*d[0]
+		}
+	}
+	var owner board.Field
+	switch {
+	case counts[board.PlayerOne] > 0 && counts[board.PlayerTwo] == 0:
+		owner = board.PlayerOne
+	case counts[board.PlayerTwo] > 0 && counts[board.PlayerOne] == 0:
+		owner = board.PlayerTwo
+	default:
+		return 0
+	}
+	score := 0
+	switch counts[owner] {
+	case b.Goal() - 1:
+		score = w.Three
+		oddRow := (b.Rows()-emptyRow)%2 == 1
+		if oddRow == (owner == board.PlayerOne) {
+			score += w.Threat
+		}
+	case 2:
+		score = w.Two
+	}
+	if owner != field {
+		return -score
+	}
+	return score
+}
@@ -0,0 +1,78 @@
+package evaluation
+
+import (
+	"4iar/board"
+	"testing"
+)
+
+var scoreTests = []struct {
+	fields [][]board.Field
+	score  int
+}{
+	{
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+		},
+		0,
+	},
+	{
+		// a single field in the center column
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0, 0},
+		},
+		DefaultWeights.Center,
+	},
+	{
+		// two in the bottom row, with the other windows containing them
+		// blocked by player two
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{1, 1, 0, 0, 2, 0, 0},
+		},
+		DefaultWeights.Two,
+	},
+	{
+		// three in the bottom row (an odd row) with the completion empty, and
+		// two of player two in the first column
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{2, 0, 0, 0, 0, 0, 0},
+			{2, 1, 1, 1, 0, 2, 0},
+		},
+		DefaultWeights.Three + DefaultWeights.Threat + DefaultWeights.Center -
+			DefaultWeights.Two,
+	},
+}
+
+func TestScore(t *testing.T) {
+	for _, test := range scoreTests {
+		b, err := board.FromFields(test.fields)
+		if err != nil {
+			t.Fatalf("create board from fields %v: %v", test.fields, err)
+		}
+		if got := Score(b, board.PlayerOne); got != test.score {
+			t.Errorf("expected score %d for board \n%v\n, got %d", test.score, b, got)
+		}
+		if got := Score(b, board.PlayerTwo); got != -test.score {
+			t.Errorf("expected score %d for player two on board \n%v\n, got %d",
+				-test.score, b, got)
+		}
+	}
+}
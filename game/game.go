@@ -4,13 +4,23 @@ package game
 import (
 	"4iar/board"
 	"4iar/player"
+	"context"
+	"errors"
 	"fmt"
+	"time"
 )
 
 // Game represents a game of two players against one another.
 type Game struct {
 	PlayerOne *player.Player
 	PlayerTwo *player.Player
+
+	// MoveTime is the time a player has to pick a single move; unlimited, if
+	// zero.
+	MoveTime time.Duration
+	// GameTime is the time a player has to pick all moves of the game;
+	// unlimited, if zero.
+	GameTime time.Duration
 }
 
 // NewGame creates a new game with the two players in the given order playing
@@ -24,10 +34,28 @@ func NewGame(playerOne, playerTwo *player.Player) *Game {
 }
 
 // Play plays through the game until a winner is found, or the board has been
-// filled without a player winning, in which case the game is tied. The outcome
-// is returned.
-func (g *Game) Play(output bool) (board.Outcome, error) {
+// filled without a player winning, in which case the game is tied. A player
+// loses the game by forfeit when exceeding the time limits (Timeout), failing
+// to play a move (PlayerError), playing an invalid move (IllegalMove), or
+// panicking (Panic). The record of the game is returned, which is incomplete
+// if an error occurred.
+func (g *Game) Play(output bool) (*Record, error) {
+	return g.PlayContext(context.Background(), output)
+}
+
+// PlayContext works like Play, but stops the game when ctx is done, in which
+// case the game's outcome is Undecided, and its termination Cancelled.
+func (g *Game) PlayContext(ctx context.Context, output bool) (*Record, error) {
 	b := board.NewBoard()
+	record := &Record{
+		Outcome: board.Undecided,
+		Board:   b,
+		Start:   time.Now(),
+	}
+	defer func() {
+		record.Duration = time.Since(record.Start)
+	}()
+	used := make(map[board.Field]time.Duration)
 	activePlayer := g.PlayerTwo
 	finished := false
 	for !finished {
@@ -36,22 +64,83 @@ func (g *Game) Play(output bool) (board.Outcome, error) {
 		} else {
 			activePlayer = g.PlayerOne
 		}
+		p := player.Adapt(activePlayer)
+		field := p.Field()
 		validMoves := b.ValidMoves()
-		move := (*activePlayer).Play(b.Copy())
-		if !board.Contains(validMoves, *move) {
-			return board.Undecided, fmt.Errorf("illgal move %v from player %v", move, activePlayer)
+		move, duration, err := g.pick(ctx, p, b.Copy(), used[field])
+		used[field] += duration
+		if ctx.Err() != nil {
+			record.end(board.Undecided, Cancelled, ctx.Err())
+			return record, nil
 		}
-		brd, outcome, err := b.Play(*move, (*activePlayer).Field())
-		b = brd
+		var panicErr *player.PanicError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			record.forfeit(field, Timeout, err)
+			return record, nil
+		case errors.As(err, &panicErr):
+			record.forfeit(field, Panic, err)
+			return record, nil
+		case err != nil:
+			record.forfeit(field, PlayerError, err)
+			return record, nil
+		case !board.Contains(validMoves, move):
+			record.forfeit(field, IllegalMove, fmt.Errorf("move %d: %w", move, board.ErrorInvalidMove))
+			return record, nil
+		}
+		brd, outcome, err := b.Play(move, field)
 		if err != nil {
-			return board.Undecided, fmt.Errorf("apply move %v to board %v: %v", move, b, err)
+			return record, fmt.Errorf("apply move %v to board %v: %v", move, b, err)
 		}
+		b = brd
+		record.add(Turn{field, move, outcome, duration}, b)
 		if output {
 			fmt.Println(brd)
 		}
 		if outcome != board.Undecided {
-			return outcome, nil
+			return record, nil
+		}
+	}
+	return record, nil
+}
+
+// pick lets player p pick a move on board b within the time left, given that
+// the player already used the time used in this game. The time taken is
+// returned, and context.DeadlineExceeded, if the player exceeded the time
+// left.
+func (g *Game) pick(ctx context.Context, p player.ContextPlayer, b *board.Board,
+	used time.Duration) (board.Move, time.Duration, error) {
+	start := time.Now()
+	if g.MoveTime <= 0 && g.GameTime <= 0 {
+		move, err := player.SafePlay(ctx, p, b)
+		return move, time.Since(start), err
+	}
+	left := g.MoveTime
+	if g.GameTime > 0 && (left <= 0 || g.GameTime-used < left) {
+		left = g.GameTime - used
+	}
+	if left <= 0 {
+		return 0, 0, context.DeadlineExceeded
+	}
+	ctx, cancel := context.WithTimeout(ctx, left)
+	defer cancel()
+	type result struct {
+		move board.Move
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		move, err := player.SafePlay(ctx, p, b)
+		results <- result{move, err}
+	}()
+	select {
+	case r := <-results:
+		duration := time.Since(start)
+		if duration > left {
+			return r.move, duration, context.DeadlineExceeded
 		}
+		return r.move, duration, r.err
+	case <-ctx.Done():
+		return 0, time.Since(start), ctx.Err()
 	}
-	return board.Undecided, nil
 }
@@ -0,0 +1,253 @@
+package game
+
+import (
+	"4iar/board"
+	"4iar/player"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// slowPlayer plays the leftmost valid move after sleeping for delay.
+type slowPlayer struct {
+	field board.Field
+	delay time.Duration
+}
+
+func newSlowPlayer(field board.Field, delay time.Duration) *player.Player {
+	p := player.Player(&slowPlayer{field, delay})
+	return &p
+}
+
+func (p *slowPlayer) Play(b *board.Board) *board.Move {
+	time.Sleep(p.delay)
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *slowPlayer) Field() board.Field {
+	return p.field
+}
+
+var timeControlTests = []struct {
+	moveTime    time.Duration
+	gameTime    time.Duration
+	delayOne    time.Duration
+	delayTwo    time.Duration
+	outcome     board.Outcome
+	termination Termination
+	turns       int
+}{
+	{0, 0, 0, 0, board.PlayerOneWins, Regular, 19},
+	{20 * time.Millisecond, 0, 0, 0, board.PlayerOneWins, Regular, 19},
+	{20 * time.Millisecond, 0, 0, 50 * time.Millisecond, board.PlayerOneWins, Timeout, 1},
+	{20 * time.Millisecond, 0, 50 * time.Millisecond, 0, board.PlayerTwoWins, Timeout, 0},
+	{0, 50 * time.Millisecond, 0, 20 * time.Millisecond, board.PlayerOneWins, Timeout, 5},
+}
+
+func TestTimeControls(t *testing.T) {
+	for _, test := range timeControlTests {
+		g := NewGame(newSlowPlayer(board.PlayerOne, test.delayOne),
+			newSlowPlayer(board.PlayerTwo, test.delayTwo))
+		g.MoveTime = test.moveTime
+		g.GameTime = test.gameTime
+		record, err := g.Play(false)
+		if err != nil {
+			t.Fatalf("play game: %v", err)
+		}
+		if record.Outcome != test.outcome || record.Termination != test.termination {
+			t.Errorf("expected outcome %d by %v, got %d by %v", test.outcome, test.termination,
+				record.Outcome, record.Termination)
+		}
+		if len(record.Turns) != test.turns {
+			t.Errorf("expected %d turns, got %d", test.turns, len(record.Turns))
+		}
+	}
+}
+
+// failingPlayer plays the leftmost valid move until it has played moves
+// moves, and then fails with err.
+type failingPlayer struct {
+	field board.Field
+	moves int
+	err   error
+}
+
+func (p *failingPlayer) Play(b *board.Board) *board.Move {
+	move, err := p.PlayContext(context.Background(), b)
+	if err != nil {
+		return nil
+	}
+	return &move
+}
+
+func (p *failingPlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	if p.moves == 0 {
+		return 0, p.err
+	}
+	p.moves--
+	return b.ValidMoves()[0], nil
+}
+
+func (p *failingPlayer) Field() board.Field {
+	return p.field
+}
+
+// nilPlayer plays the leftmost valid move until it has played moves moves, and
+// then nil, without implementing player.ContextPlayer.
+type nilPlayer struct {
+	field board.Field
+	moves int
+}
+
+func (p *nilPlayer) Play(b *board.Board) *board.Move {
+	if p.moves == 0 {
+		return nil
+	}
+	p.moves--
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *nilPlayer) Field() board.Field {
+	return p.field
+}
+
+// panickingPlayer plays the leftmost valid move until it has played moves
+// moves, and then panics.
+type panickingPlayer struct {
+	field board.Field
+	moves int
+}
+
+func (p *panickingPlayer) Play(b *board.Board) *board.Move {
+	if p.moves == 0 {
+		panic("out of cheese")
+	}
+	p.moves--
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *panickingPlayer) Field() board.Field {
+	return p.field
+}
+
+// illegalPlayer plays the given column, regardless whether it is full or not.
+type illegalPlayer struct {
+	field  board.Field
+	column board.Move
+}
+
+func (p *illegalPlayer) Play(b *board.Board) *board.Move {
+	return &p.column
+}
+
+func (p *illegalPlayer) Field() board.Field {
+	return p.field
+}
+
+var (
+	errOutOfCheese   = errors.New("out of cheese")
+	errSearchTimeout = fmt.Errorf("search: %w", context.DeadlineExceeded)
+)
+
+var errorTests = []struct {
+	// newPlayerTwo creates a fresh player two for every run of a test, as
+	// some players count their moves.
+	newPlayerTwo func() player.Player
+	moveTime     time.Duration
+	termination  Termination
+	err          error
+	turns        int
+}{
+	{func() player.Player { return &failingPlayer{board.PlayerTwo, 2, errOutOfCheese} },
+		0, PlayerError, errOutOfCheese, 5},
+	{func() player.Player { return &failingPlayer{board.PlayerTwo, 1, errSearchTimeout} },
+		0, Timeout, context.DeadlineExceeded, 3},
+	{func() player.Player { return &nilPlayer{board.PlayerTwo, 1} },
+		0, PlayerError, player.ErrorNoMove, 3},
+	{func() player.Player { return &panickingPlayer{board.PlayerTwo, 1} },
+		0, Panic, nil, 3},
+	{func() player.Player { return &panickingPlayer{board.PlayerTwo, 1} },
+		time.Second, Panic, nil, 3},
+	{func() player.Player { return &illegalPlayer{board.PlayerTwo, 7} },
+		0, IllegalMove, board.ErrorInvalidMove, 1},
+	{func() player.Player { return &illegalPlayer{board.PlayerTwo, 0} },
+		0, IllegalMove, board.ErrorInvalidMove, 7},
+}
+
+func TestPlayerErrors(t *testing.T) {
+	for _, test := range errorTests {
+		playerTwo := test.newPlayerTwo()
+		g := NewGame(newSlowPlayer(board.PlayerOne, 0), &playerTwo)
+		g.MoveTime = test.moveTime
+		record, err := g.Play(false)
+		if err != nil {
+			t.Fatalf("play game: %v", err)
+		}
+		if record.Outcome != board.PlayerOneWins || record.Termination != test.termination {
+			t.Errorf("expected player one to win by %v, got %d by %v", test.termination,
+				record.Outcome, record.Termination)
+		}
+		if record.Forfeiter != board.PlayerTwo {
+			t.Errorf("expected player two to forfeit, got %d", record.Forfeiter)
+		}
+		var panicErr *player.PanicError
+		if test.termination == Panic && !errors.As(record.Err, &panicErr) {
+			t.Errorf("expected panic error, got %v", record.Err)
+		}
+		if test.err != nil && !errors.Is(record.Err, test.err) {
+			t.Errorf("expected error %v, got %v", test.err, record.Err)
+		}
+		if len(record.Turns) != test.turns {
+			t.Errorf("expected %d turns, got %d", test.turns, len(record.Turns))
+		}
+	}
+}
+
+func TestPlayContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := NewGame(newSlowPlayer(board.PlayerOne, 0), newSlowPlayer(board.PlayerTwo, 0))
+	record, err := g.PlayContext(ctx, false)
+	if err != nil {
+		t.Fatalf("play game: %v", err)
+	}
+	if record.Outcome != board.Undecided || record.Termination != Cancelled {
+		t.Errorf("expected undecided game by %v, got %d by %v", Cancelled, record.Outcome,
+			record.Termination)
+	}
+	if !errors.Is(record.Err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, record.Err)
+	}
+}
+
+// searchingPlayers are players that search until shortly before the deadline
+// of a move, if not limited otherwise.
+var searchingPlayers = map[string]func(board.Field) *player.Player{
+	"alphabeta": func(f board.Field) *player.Player {
+		return player.NewAlphaBetaPlayer(f, board.MaxFields, nil)
+	},
+	"mcts": func(f board.Field) *player.Player {
+		return player.NewMCTSPlayer(f, 0, time.Hour, 1)
+	},
+}
+
+func TestSearchingPlayersMoveInTime(t *testing.T) {
+	// the players must meet the deadline, even if the machine is busy
+	for name, newPlayer := range searchingPlayers {
+		g := NewGame(newPlayer(board.PlayerOne), newPlayer(board.PlayerTwo))
+		g.MoveTime = 50 * time.Millisecond
+		record, err := g.Play(false)
+		if err != nil {
+			t.Fatalf("play game: %v", err)
+		}
+		if record.Termination != Regular {
+			t.Errorf("expected game of %s players to end regularly, got %v after %d turns: %v",
+				name, record.Termination, len(record.Turns), record.Err)
+		}
+	}
+}
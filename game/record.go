@@ -0,0 +1,167 @@
+package game
+
+import (
+	"4iar/board"
+	"4iar/notation"
+	"fmt"
+	"time"
+)
+
+// Termination is the reason a game ended.
+type Termination int
+
+const (
+	// Regular is the termination of a game decided on the board, i.e. by a
+	// win or a tie.
+	Regular Termination = iota
+	// Timeout is the termination of a game lost by a player who exceeded the
+	// time limits.
+	Timeout
+	// PlayerError is the termination of a game lost by a player who failed
+	// to play a move.
+	PlayerError
+	// Cancelled is the termination of a game that was stopped before it was
+	// decided.
+	Cancelled
+	// IllegalMove is the termination of a game lost by a player who played
+	// a move that is not valid on the board.
+	IllegalMove
+	// Panic is the termination of a game lost by a player who panicked while
+	// picking a move.
+	Panic
+)
+
+// Forfeit returns true if the termination means that a player lost the game
+// for another reason than the position on the board.
+func (t Termination) Forfeit() bool {
+	return t == Timeout || t == PlayerError || t == IllegalMove || t == Panic
+}
+
+func (t Termination) String() string {
+	switch t {
+	case Regular:
+		return "regular"
+	case Timeout:
+		return "timeout"
+	case PlayerError:
+		return "player error"
+	case Cancelled:
+		return "cancelled"
+	case IllegalMove:
+		return "illegal move"
+	case Panic:
+		return "panic"
+	}
+	return fmt.Sprintf("termination(%d)", int(t))
+}
+
+// Turn is a single move played in a game.
+type Turn struct {
+	// Field is the field of the player who played the move.
+	Field board.Field
+	// Move is the column played.
+	Move board.Move
+	// Outcome is the outcome of the game after the move.
+	Outcome board.Outcome
+	// Duration is the time the player took to pick the move.
+	Duration time.Duration
+}
+
+// Record is the record of a game, listing every move played.
+type Record struct {
+	// Turns are the moves played in order.
+	Turns []Turn
+	// Outcome is the final outcome of the game, or Undecided, if the game
+	// was not played to its end.
+	Outcome board.Outcome
+	// Termination is the reason the game ended. Unless it is Regular, the
+	// outcome was not reached on the board: the player to move after the
+	// last turn lost the game, or the game was Cancelled.
+	Termination Termination
+	// Err is the error that ended the game, unless its termination is
+	// Regular.
+	Err error
+	// Forfeiter is the field of the player who lost the game by forfeit, or
+	// Empty, if the game's termination is no forfeit.
+	Forfeiter board.Field
+	// Board is the board after the last move.
+	Board *board.Board
+	// Start is the time the game started.
+	Start time.Time
+	// Duration is the time the whole game took.
+	Duration time.Duration
+}
+
+// Moves returns the moves of the game in the order they were played.
+func (r *Record) Moves() []board.Move {
+	moves := make([]board.Move, len(r.Turns))
+	for i, turn := range r.Turns {
+		moves[i] = turn.Move
+	}
+	return moves
+}
+
+// CanonicalMoves returns the moves of the game, mirrored left-right if the
+// first move off the center column was played right of it, so that mirrored
+// games have the same canonical moves.
+func (r *Record) CanonicalMoves() []board.Move {
+	moves := r.Moves()
+	cols := r.Board.Cols()
+	for _, move := range moves {
+		if mirrored := move.Mirror(cols); mirrored != move {
+			if mirrored < move {
+				for i := range moves {
+					moves[i] = moves[i].Mirror(cols)
+				}
+			}
+			break
+		}
+	}
+	return moves
+}
+
+// Notation returns the moves of the game in the notation of package notation.
+func (r *Record) Notation() string {
+	return notation.Format(r.Moves())
+}
+
+// Boards replays the game and returns the board after every move, starting
+// with the empty board.
+func (r *Record) Boards() ([]*board.Board, error) {
+	b, err := board.NewCustomBoard(r.Board.Rows(), r.Board.Cols(), r.Board.Goal())
+	if err != nil {
+		return nil, fmt.Errorf("create board: %v", err)
+	}
+	boards := []*board.Board{b}
+	for i, turn := range r.Turns {
+		next, outcome, err := b.Play(turn.Move, turn.Field)
+		if err != nil {
+			return nil, fmt.Errorf("replay move %d (%v): %v", i+1, turn.Move, err)
+		}
+		if outcome != turn.Outcome {
+			return nil, fmt.Errorf("replay move %d (%v): outcome %d differs from %d",
+				i+1, turn.Move, outcome, turn.Outcome)
+		}
+		boards = append(boards, next)
+		b = next
+	}
+	return boards, nil
+}
+
+func (r *Record) end(outcome board.Outcome, termination Termination, err error) {
+	r.Outcome = outcome
+	r.Termination = termination
+	r.Err = err
+}
+
+// forfeit ends the game as lost by the player with the given field.
+func (r *Record) forfeit(field board.Field, termination Termination, err error) {
+	r.end(board.Outcome(field.Opponent()), termination, fmt.Errorf("player %d: %w", field, err))
+	r.Forfeiter = field
+}
+
+func (r *Record) add(turn Turn, b *board.Board) {
+	r.Turns = append(r.Turns, turn)
+	r.Outcome = turn.Outcome
+	r.Board = b
+}
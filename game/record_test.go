@@ -0,0 +1,70 @@
+package game
+
+import (
+	"4iar/board"
+	"4iar/notation"
+	"4iar/player"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	g := NewGame(player.NewGreedyPlayer(board.PlayerOne),
+		player.NewRandomPlayer(board.PlayerTwo, 1))
+	record, err := g.Play(false)
+	if err != nil {
+		t.Fatalf("play game: %v", err)
+	}
+	if record.Outcome == board.Undecided {
+		t.Errorf("expected game to be decided, was undecided")
+	}
+	for i, turn := range record.Turns {
+		if expected := board.Field(i%2 + 1); turn.Field != expected {
+			t.Errorf("expected move %d to be played by %d, was %d", i+1, expected, turn.Field)
+		}
+		last := i == len(record.Turns)-1
+		if last != (turn.Outcome != board.Undecided) {
+			t.Errorf("expected only the last move to decide the game, move %d has outcome %d",
+				i+1, turn.Outcome)
+		}
+	}
+	boards, err := record.Boards()
+	if err != nil {
+		t.Fatalf("replay game: %v", err)
+	}
+	if len(boards) != len(record.Turns)+1 {
+		t.Errorf("expected %d boards, got %d", len(record.Turns)+1, len(boards))
+	}
+	if final := boards[len(boards)-1]; !final.Equal(record.Board) {
+		t.Errorf("expected replayed board \n%v\n, got \n%v\n", record.Board, final)
+	}
+	parsed, _, err := notation.Parse(record.Notation())
+	if err != nil {
+		t.Fatalf("parse notation %q: %v", record.Notation(), err)
+	}
+	if !parsed.Equal(record.Board) {
+		t.Errorf("expected board \n%v\n for %q, got \n%v\n", record.Board, record.Notation(), parsed)
+	}
+	if len(record.Moves()) != len(record.Turns) {
+		t.Errorf("expected %d moves, got %d", len(record.Turns), len(record.Moves()))
+	}
+}
+
+func TestCanonicalMoves(t *testing.T) {
+	one := &Record{Board: board.NewBoard()}
+	two := &Record{Board: board.NewBoard()}
+	for i, move := range []board.Move{3, 3, 5, 1, 0} {
+		one.Turns = append(one.Turns, Turn{Field: board.Field(i%2 + 1), Move: move})
+		two.Turns = append(two.Turns, Turn{Field: board.Field(i%2 + 1),
+			Move: move.Mirror(board.Cols)})
+	}
+	expected := "44267"
+	if got := notation.Format(one.CanonicalMoves()); got != expected {
+		t.Errorf("expected canonical moves %q, got %q", expected, got)
+	}
+	if got := notation.Format(two.CanonicalMoves()); got != expected {
+		t.Errorf("expected canonical moves %q of mirrored game, got %q", expected, got)
+	}
+	if got := notation.Format(one.Moves()); got == expected {
+		t.Errorf("expected moves of the game to be left unchanged, got %q", got)
+	}
+}
@@ -1,25 +1,154 @@
 package main
 
 import (
+	"4iar/board"
+	"4iar/evaluation"
 	"4iar/player"
 	"4iar/tournament"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"os/signal"
+	"time"
 )
 
 func main() {
-	numberOfRounds := flag.Int("n", 1, "number of rounds to play (with match and rematch)")
+	numberOfRounds := flag.Int("n", 1,
+		"number of rounds to play (with match and rematch in roundrobin and gauntlet mode)")
+	mode := flag.String("mode", "roundrobin",
+		"tournament mode (roundrobin, swiss, knockout, or gauntlet)")
+	challenger := flag.String("challenger", "Alfie Beta", "challenger in gauntlet mode")
+	double := flag.Bool("double", false, "double elimination in knockout mode")
+	bestOf := flag.Int("bestof", 1, "number of games per series in knockout mode")
+	tieBreak := flag.String("tiebreak", tournament.SuddenDeath.String(),
+		"tie-break rule for drawn series in knockout mode (suddendeath or seed)")
+	bracketFile := flag.String("bracket", "", "file to export the knockout bracket to as JSON")
+	kFactor := flag.Float64("k", tournament.DefaultKFactor, "Elo K-factor")
+	initialRating := flag.Float64("elo", tournament.DefaultInitialRating, "initial Elo rating")
+	moveTime := flag.Duration("movetime", 0, "time per move (0 for unlimited)")
+	gameTime := flag.Duration("gametime", 0, "time per player and game (0 for unlimited)")
+	errorPolicy := flag.String("errors", tournament.CountAsLoss.String(),
+		"scoring of forfeited games (loss, void, or abort)")
+	concurrency := flag.Int("concurrency", 0,
+		"maximum number of games played at the same time (0 for the number of CPUs)")
+	progress := flag.Bool("progress", false, "report progress on standard error")
+	seed := flag.Int64("seed", 0, "master seed for all players (0 for a random one)")
 	flag.Parse()
 	if *numberOfRounds < 1 {
 		log.Fatalf("unable to play tournament with %d rounds", *numberOfRounds)
 	}
+	policy, err := tournament.ParseErrorPolicy(*errorPolicy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	knockout := tournament.Knockout{BestOf: *bestOf}
+	if *double {
+		knockout.Elimination = tournament.DoubleElimination
+	}
+	if knockout.TieBreak, err = tournament.ParseTieBreak(*tieBreak); err != nil {
+		log.Fatal(err)
+	}
 	t := tournament.NewTournament()
+	t.KFactor = *kFactor
+	t.InitialRating = *initialRating
+	t.MoveTime = *moveTime
+	t.GameTime = *gameTime
+	t.ErrorPolicy = policy
+	t.Concurrency = *concurrency
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	t.Seed = *seed
+	fmt.Printf("Seed: %d\n\n", t.Seed)
+	if *progress {
+		t.Progress = reportProgress()
+	}
 	t.AddPlayer("Randy Random", player.NewRandomPlayer)
 	t.AddPlayer("Winnie Move", player.NewWinningMovePlayer)
-	result, err := t.Play(*numberOfRounds)
+	t.AddPlayer("Greta Greedy", func(f board.Field, seed int64) *player.Player {
+		return player.NewGreedyPlayer(f)
+	})
+	t.AddPlayer("Minnie Max", func(f board.Field, seed int64) *player.Player {
+		return player.NewMinimaxPlayer(f, 4, nil)
+	})
+	t.AddPlayer("Alfie Beta", func(f board.Field, seed int64) *player.Player {
+		return player.NewAlphaBetaPlayer(f, 8, evaluation.Score)
+	})
+	t.AddPlayer("Monty Carlo", func(f board.Field, seed int64) *player.Player {
+		return player.NewMCTSPlayer(f, 5000, 0, seed)
+	})
+	// stop on interrupt, printing the results so far
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+	var result *tournament.Result
+	switch *mode {
+	case "roundrobin":
+		result, err = t.PlayContext(ctx, *numberOfRounds)
+	case "swiss":
+		result, err = t.PlaySwiss(ctx, *numberOfRounds)
+	case "knockout":
+		var bracket *tournament.Bracket
+		bracket, err = t.PlayKnockout(ctx, knockout)
+		if bracket != nil {
+			fmt.Println(bracket)
+			result = bracket.Result
+			if *bracketFile != "" {
+				if err := exportBracket(*bracketFile, bracket); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+	case "gauntlet":
+		var gauntlet *tournament.Gauntlet
+		gauntlet, err = t.PlayGauntlet(ctx, *challenger, *numberOfRounds)
+		if gauntlet != nil {
+			fmt.Println(gauntlet)
+		}
+	default:
+		log.Fatalf("unknown tournament mode '%s'", *mode)
+	}
+	if result != nil {
+		fmt.Println(result)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println(result)
+}
+
+// reportProgress returns a progress function that reports the number of games
+// played and the leader at most once a second.
+func reportProgress() func(tournament.Progress) {
+	var last time.Time
+	return func(p tournament.Progress) {
+		if time.Since(last) < time.Second && p.Done != p.Total {
+			return
+		}
+		last = time.Now()
+		leader := p.Standings[0]
+		if p.Total > 0 {
+			fmt.Fprintf(os.Stderr, "%d/%d games, ETA %v, leader %s (%d points)\n", p.Done,
+				p.Total, p.ETA.Round(time.Second), leader.PlayerName, leader.Points)
+		} else {
+			fmt.Fprintf(os.Stderr, "%d games, %v elapsed, leader %s (%d points)\n", p.Done,
+				p.Elapsed.Round(time.Second), leader.PlayerName, leader.Points)
+		}
+	}
+}
+
+func exportBracket(path string, bracket *tournament.Bracket) error {
+	data, err := json.MarshalIndent(bracket, "", "  ")
+	if err != nil {
+		return fmt.Errorf("export bracket: %w", err)
+	}
+	return ioutil.WriteFile(path, data, 0644)
 }
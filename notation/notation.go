@@ -0,0 +1,84 @@
+// Package notation converts between boards and a compact text notation of
+// the moves leading to them, in which every move is written as the number of
+// its column, counted from 1, e.g. "4453" for two moves in the center column,
+// followed by moves in the fifth and third column. Player one moves first.
+package notation
+
+import (
+	"4iar/board"
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+var (
+	// ErrorInvalidColumn indicates a character that doesn't denote a column
+	// of the board.
+	ErrorInvalidColumn = errors.New("invalid column")
+	// ErrorColumnFull indicates a move into a column without empty fields.
+	ErrorColumnFull = errors.New("column is full")
+	// ErrorGameOver indicates a move after the game has been decided.
+	ErrorGameOver = errors.New("game is already over")
+	// ErrorTooManyColumns indicates a board whose columns cannot be written
+	// as single digits.
+	ErrorTooManyColumns = errors.New("too many columns for notation")
+)
+
+// maxCols is the highest column number that can be written as a digit.
+const maxCols = 9
+
+// Parse plays the moves written in notation s on a new board with the classic
+// dimensions, and returns the resulting board and the field of the player to
+// move next. Whitespace in s is ignored.
+func Parse(s string) (*board.Board, board.Field, error) {
+	return ParseOn(board.NewBoard(), s)
+}
+
+// ParseOn works like Parse, but plays the moves on board b, which is not
+// modified. The player to move first is determined by the number of moves
+// already played on b.
+func ParseOn(b *board.Board, s string) (*board.Board, board.Field, error) {
+	if b.Cols() > maxCols {
+		return nil, board.Empty, ErrorTooManyColumns
+	}
+	field := board.PlayerOne
+	if b.Plies()%2 == 1 {
+		field = board.PlayerTwo
+	}
+	outcome := board.Undecided
+	n := 0
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		n++
+		if outcome != board.Undecided {
+			return nil, board.Empty, fmt.Errorf("move %d ('%c'): %w", n, r, ErrorGameOver)
+		}
+		col := int(r - '1')
+		if r < '1' || r > '9' || col >= b.Cols() {
+			return nil, board.Empty, fmt.Errorf("move %d ('%c'): %w", n, r, ErrorInvalidColumn)
+		}
+		next, o, err := b.Play(board.Move(col), field)
+		if err != nil {
+			return nil, board.Empty, fmt.Errorf("move %d ('%c'): %w", n, r, ErrorColumnFull)
+		}
+		b, outcome, field = next, o, field.Opponent()
+	}
+	return b, field, nil
+}
+
+// Format writes the moves in notation. Moves outside of the columns that can
+// be written as digits are written as '?'.
+func Format(moves []board.Move) string {
+	buf := bytes.NewBufferString("")
+	for _, m := range moves {
+		if m < 0 || m >= maxCols {
+			buf.WriteRune('?')
+			continue
+		}
+		buf.WriteRune(rune('1' + m))
+	}
+	return buf.String()
+}
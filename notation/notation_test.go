@@ -0,0 +1,111 @@
+package notation
+
+import (
+	"4iar/board"
+	"errors"
+	"testing"
+)
+
+var parseTests = []struct {
+	notation string
+	fields   [][]board.Field
+	field    board.Field
+}{
+	{
+		"",
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+		},
+		board.PlayerOne,
+	},
+	{
+		"4453",
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 2, 0, 0, 0},
+			{0, 0, 2, 1, 1, 0, 0},
+		},
+		board.PlayerOne,
+	},
+	{
+		"17 27 37 4",
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 2},
+			{0, 0, 0, 0, 0, 0, 2},
+			{1, 1, 1, 1, 0, 0, 2},
+		},
+		board.PlayerTwo,
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range parseTests {
+		b, field, err := Parse(test.notation)
+		if err != nil {
+			t.Errorf("parse %q: %v", test.notation, err)
+			continue
+		}
+		expected, err := board.FromFields(test.fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !b.Equal(expected) {
+			t.Errorf("expected %q to be \n%v\n, got \n%v\n", test.notation, expected, b)
+		}
+		if field != test.field {
+			t.Errorf("expected player %d to move after %q, got %d", test.field, test.notation, field)
+		}
+	}
+}
+
+var parseErrorTests = []struct {
+	notation string
+	err      error
+}{
+	{"408", ErrorInvalidColumn},
+	{"44a", ErrorInvalidColumn},
+	{"448", ErrorInvalidColumn},
+	{"4444444", ErrorColumnFull},
+	{"17273741", ErrorGameOver},
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range parseErrorTests {
+		_, _, err := Parse(test.notation)
+		if !errors.Is(err, test.err) {
+			t.Errorf("expected error %v for %q, got %v", test.err, test.notation, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	notation := "4453671"
+	b := board.NewBoard()
+	field := board.PlayerOne
+	moves := []board.Move{3, 3, 4, 2, 5, 6, 0}
+	for _, m := range moves {
+		b, _, _ = b.Play(m, field)
+		field = field.Opponent()
+	}
+	if got := Format(moves); got != notation {
+		t.Errorf("expected notation %q, got %q", notation, got)
+	}
+	parsed, _, err := Parse(notation)
+	if err != nil {
+		t.Fatalf("parse %q: %v", notation, err)
+	}
+	if !parsed.Equal(b) {
+		t.Errorf("expected %q to be \n%v\n, got \n%v\n", notation, b, parsed)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"4iar/board"
+	"4iar/game"
+	"4iar/player"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+func main() {
+	names := strings.Join(player.Names(), ", ")
+	one := flag.String("one", "human", "first player ("+names+")")
+	two := flag.String("two", "alphabeta", "second player ("+names+")")
+	seed := flag.Int64("seed", 0, "master seed for both players (0 for a random one)")
+	flag.Parse()
+	spawnOne, ok := player.Registry[*one]
+	if !ok {
+		log.Fatalf("unknown player '%s', choose one of: %s", *one, names)
+	}
+	spawnTwo, ok := player.Registry[*two]
+	if !ok {
+		log.Fatalf("unknown player '%s', choose one of: %s", *two, names)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	g := game.NewGame(spawnOne(board.PlayerOne, player.DeriveSeed(*seed, 0)),
+		spawnTwo(board.PlayerTwo, player.DeriveSeed(*seed, 1)))
+	record, err := g.Play(false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if errors.Is(record.Err, player.ErrorNoMove) {
+		fmt.Println("Game aborted")
+		return
+	}
+	fmt.Println()
+	fmt.Print(record.Board)
+	fmt.Printf("Moves: %s\n", record.Notation())
+	fmt.Printf("Seed: %d\n", *seed)
+	switch record.Outcome {
+	case board.PlayerOneWins:
+		fmt.Printf("Player One (%s) Wins\n", *one)
+	case board.PlayerTwoWins:
+		fmt.Printf("Player Two (%s) Wins\n", *two)
+	case board.Tie:
+		fmt.Println("Tied")
+	}
+	if record.Termination != game.Regular {
+		fmt.Printf("The game ended by %v: %v\n", record.Termination, record.Err)
+	}
+}
@@ -0,0 +1,73 @@
+package player
+
+import (
+	"4iar/board"
+	"4iar/search"
+	"context"
+	"sync"
+)
+
+// AlphaBetaPlayer is a player that searches a fixed number of plies ahead
+// using negamax with alpha-beta pruning and a transposition table, which is
+// kept between moves.
+type AlphaBetaPlayer struct {
+	PlayerField board.Field
+
+	mu         sync.Mutex
+	engine     *search.Engine
+	lastResult search.Result
+	nodes      int
+}
+
+// NewAlphaBetaPlayer creates a new alpha-beta player searching depth plies
+// ahead, scoring undecided positions at that depth using evaluate, which may
+// be nil to score them as neutral.
+func NewAlphaBetaPlayer(field board.Field, depth int, evaluate search.Evaluator) *Player {
+	alphaBetaPlayer := AlphaBetaPlayer{
+		PlayerField: field,
+		engine:      search.NewEngine(depth, evaluate, search.DefaultTableSize),
+	}
+	p := Player(&alphaBetaPlayer)
+	return &p
+}
+
+// Play searches for the best move.
+func (p *AlphaBetaPlayer) Play(b *board.Board) *board.Move {
+	return play(p, b)
+}
+
+// PlayContext searches for the best move, returning the best move found so
+// far shortly before ctx is done.
+func (p *AlphaBetaPlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	if len(b.ValidMoves()) == 0 {
+		return 0, ErrorNoMove
+	}
+	ctx, cancel := withSafetyMargin(ctx)
+	defer cancel()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := p.engine.SearchContext(ctx, b, p.PlayerField)
+	p.lastResult = result
+	p.nodes += result.Nodes
+	return result.Move, nil
+}
+
+// LastResult returns the result of the most recent search, including the
+// number of nodes searched and the principal variation.
+func (p *AlphaBetaPlayer) LastResult() search.Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastResult
+}
+
+// Nodes returns the total number of nodes searched by the player.
+func (p *AlphaBetaPlayer) Nodes() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nodes
+}
+
+// Field returns the field assigned to the player.
+func (p *AlphaBetaPlayer) Field() board.Field {
+	return p.PlayerField
+}
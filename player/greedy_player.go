@@ -0,0 +1,64 @@
+package player
+
+import (
+	"4iar/board"
+	"4iar/evaluation"
+	"4iar/search"
+	"context"
+	"fmt"
+)
+
+// GreedyPlayer is a player that looks ahead a single ply, playing a winning
+// move if there is one, and otherwise the move leading to the position with
+// the best evaluation.
+type GreedyPlayer struct {
+	PlayerField board.Field
+	Evaluate    search.Evaluator
+}
+
+// NewGreedyPlayer creates a new greedy player, which evaluates positions using
+// evaluation.Score.
+func NewGreedyPlayer(field board.Field) *Player {
+	greedyPlayer := GreedyPlayer{field, evaluation.Score}
+	p := Player(&greedyPlayer)
+	return &p
+}
+
+// Play picks the move with the best evaluation. Ties between equally scored
+// moves are broken in favour of the move closest to the center column, and
+// then of the leftmost one.
+func (p *GreedyPlayer) Play(b *board.Board) *board.Move {
+	return play(p, b)
+}
+
+// PlayContext works like Play, but reports failures as errors.
+func (p *GreedyPlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	candidates := board.CenterFirst(b.ValidMoves(), b.Cols())
+	if len(candidates) == 0 {
+		return 0, ErrorNoMove
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	best := -1
+	bestScore := 0
+	for i, candidate := range candidates {
+		next, outcome, err := b.Play(candidate, p.PlayerField)
+		if err != nil {
+			return 0, fmt.Errorf("play move %v on board %v: %w", candidate, b, err)
+		}
+		if outcome == board.Outcome(p.PlayerField) {
+			return candidate, nil
+		}
+		score := p.Evaluate(next, p.PlayerField)
+		if best == -1 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return candidates[best], nil
+}
+
+// Field returns the field assigned to the player.
+func (p *GreedyPlayer) Field() board.Field {
+	return p.PlayerField
+}
@@ -0,0 +1,86 @@
+package player
+
+import (
+	"4iar/board"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HumanPlayer is a player that asks a human for moves: the board is printed to
+// an output, and the column to be played is read from an input.
+type HumanPlayer struct {
+	PlayerField board.Field
+
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// stdin is shared by all human players reading from STDIN, so that a player's
+// scanner doesn't buffer input meant for another one.
+var stdin = bufio.NewScanner(os.Stdin)
+
+// NewHumanPlayer creates a new human player interacting through STDIN and
+// STDOUT. All such players share the same input, so that two humans can play
+// against each other.
+func NewHumanPlayer(field board.Field) *Player {
+	return newHumanPlayer(field, stdin, os.Stdout)
+}
+
+// NewHumanPlayerIO creates a new human player reading moves from in and
+// writing the board and prompts to out.
+func NewHumanPlayerIO(field board.Field, in io.Reader, out io.Writer) *Player {
+	return newHumanPlayer(field, bufio.NewScanner(in), out)
+}
+
+func newHumanPlayer(field board.Field, in *bufio.Scanner, out io.Writer) *Player {
+	humanPlayer := HumanPlayer{field, in, out}
+	p := Player(&humanPlayer)
+	return &p
+}
+
+// Play prints the board and prompts for a column, counted from 1, until a
+// valid move is entered. If the human quits by entering "q" or "quit", or if
+// the input is exhausted, nil is returned.
+func (p *HumanPlayer) Play(b *board.Board) *board.Move {
+	candidates := b.ValidMoves()
+	if len(candidates) == 0 {
+		return nil
+	}
+	fmt.Fprintln(p.out)
+	fmt.Fprint(p.out, b)
+	for c := 1; c <= b.Cols(); c++ {
+		fmt.Fprintf(p.out, "%d ", c%10)
+	}
+	fmt.Fprintln(p.out)
+	for {
+		fmt.Fprintf(p.out, "Player %d, your move (1-%d, q to quit): ", p.PlayerField, b.Cols())
+		if !p.in.Scan() {
+			fmt.Fprintln(p.out)
+			return nil
+		}
+		input := strings.ToLower(strings.TrimSpace(p.in.Text()))
+		if input == "q" || input == "quit" {
+			return nil
+		}
+		col, err := strconv.Atoi(input)
+		if err != nil || col < 1 || col > b.Cols() {
+			fmt.Fprintf(p.out, "%q is not a column between 1 and %d\n", input, b.Cols())
+			continue
+		}
+		move := board.Move(col - 1)
+		if !board.Contains(candidates, move) {
+			fmt.Fprintf(p.out, "column %d is full\n", col)
+			continue
+		}
+		return &move
+	}
+}
+
+// Field returns the field assigned to the player.
+func (p *HumanPlayer) Field() board.Field {
+	return p.PlayerField
+}
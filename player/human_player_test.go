@@ -0,0 +1,70 @@
+package player
+
+import (
+	"4iar/board"
+	"bufio"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+var humanPlayerTests = []struct {
+	input string
+	move  *board.Move
+}{
+	{"4\n", movePtr(3)},
+	{" 7 \n", movePtr(6)},
+	{"0\n8\nx\n\n2\n", movePtr(1)},
+	{"1\n3\n", movePtr(2)},
+	{"q\n4\n", nil},
+	{"QUIT\n", nil},
+	{"", nil},
+	{"9\n", nil},
+}
+
+func movePtr(m board.Move) *board.Move {
+	return &m
+}
+
+func TestHumanPlayer(t *testing.T) {
+	b, err := board.FromFields([][]board.Field{
+		{1, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0},
+		{2, 0, 0, 0, 0, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range humanPlayerTests {
+		p := NewHumanPlayerIO(board.PlayerOne, strings.NewReader(test.input), ioutil.Discard)
+		got := (*p).Play(b)
+		if (got == nil) != (test.move == nil) || (got != nil && *got != *test.move) {
+			t.Errorf("expected move %v for input %q, got %v", test.move, test.input, got)
+		}
+	}
+}
+
+func TestHumanPlayersShareInput(t *testing.T) {
+	one := (*NewHumanPlayer(board.PlayerOne)).(*HumanPlayer)
+	two := (*NewHumanPlayer(board.PlayerTwo)).(*HumanPlayer)
+	if one.in != two.in {
+		t.Errorf("expected human players to share the scanner of STDIN")
+	}
+	in := bufio.NewScanner(strings.NewReader("1\n2\n1\n"))
+	players := []*Player{
+		newHumanPlayer(board.PlayerOne, in, ioutil.Discard),
+		newHumanPlayer(board.PlayerTwo, in, ioutil.Discard),
+	}
+	b := board.NewBoard()
+	for i, expected := range []board.Move{0, 1, 0} {
+		p := players[i%2]
+		got := (*p).Play(b)
+		if got == nil || *got != expected {
+			t.Fatalf("expected move %d %v, got %v", i+1, expected, got)
+		}
+		b, _, _ = b.Play(*got, (*p).Field())
+	}
+}
@@ -0,0 +1,185 @@
+package player
+
+import (
+	"4iar/board"
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultExploration is the exploration constant of the UCT formula used if
+// none is configured, which is the theoretical optimum of sqrt(2).
+const DefaultExploration = math.Sqrt2
+
+// MCTSPlayer is a player that uses Monte Carlo Tree Search with the UCT
+// selection strategy: the moves are explored by random playouts, favouring the
+// moves that have won the most playouts so far, but also trying those that
+// have been explored the least.
+type MCTSPlayer struct {
+	PlayerField board.Field
+	// Iterations is the maximum number of playouts per move; unlimited, if
+	// zero, in which case Budget must be set.
+	Iterations int
+	// Budget is the maximum time to spend per move; unlimited, if zero.
+	Budget time.Duration
+	// Exploration is the exploration constant of the UCT formula.
+	Exploration float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewMCTSPlayer creates a new MCTS player doing the given number of playouts
+// per move, or as many as possible within the budget, whatever comes first.
+// Zero means unlimited for either, but not for both, in which case a single
+// playout is done. The random number generator is seeded with seed.
+func NewMCTSPlayer(field board.Field, iterations int, budget time.Duration, seed int64) *Player {
+	if iterations <= 0 && budget <= 0 {
+		iterations = 1
+	}
+	mctsPlayer := MCTSPlayer{
+		PlayerField: field,
+		Iterations:  iterations,
+		Budget:      budget,
+		Exploration: DefaultExploration,
+		rng:         rand.New(rand.NewSource(seed)),
+	}
+	p := Player(&mctsPlayer)
+	return &p
+}
+
+// mctsNode is a node in the search tree, reached by the player with field
+// playing move.
+type mctsNode struct {
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []board.Move
+	board    *board.Board
+	move     board.Move
+	field    board.Field
+	outcome  board.Outcome
+	visits   int
+	// score is the sum of the playout results (1 for a win, 0.5 for a tie)
+	// from the perspective of the player with field.
+	score float64
+}
+
+// Play searches for the move that was played most often.
+func (p *MCTSPlayer) Play(b *board.Board) *board.Move {
+	return play(p, b)
+}
+
+// PlayContext searches for the move that was played most often, stopping
+// shortly before ctx is done.
+func (p *MCTSPlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	candidates := b.ValidMoves()
+	if len(candidates) == 0 {
+		return 0, ErrorNoMove
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	ctx, cancel := withSafetyMargin(ctx)
+	defer cancel()
+	if p.Budget > 0 {
+		ctx, cancel = context.WithTimeout(ctx, p.Budget)
+		defer cancel()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	root := &mctsNode{
+		untried: candidates,
+		board:   b,
+		field:   p.PlayerField.Opponent(),
+		outcome: board.Undecided,
+	}
+	for i := 0; p.Iterations <= 0 || i < p.Iterations; i++ {
+		if i > 0 && expired(ctx) {
+			break
+		}
+		node := p.selectNode(root)
+		if len(node.untried) > 0 {
+			node = p.expand(node)
+		}
+		outcome := node.outcome
+		if outcome == board.Undecided {
+			outcome = p.playout(node.board, node.field.Opponent())
+		}
+		for ; node != nil; node = node.parent {
+			node.visits++
+			switch outcome {
+			case board.Outcome(node.field):
+				node.score++
+			case board.Tie:
+				node.score += 0.5
+			}
+		}
+	}
+	best := root.children[0]
+	for _, child := range root.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.move, nil
+}
+
+// selectNode descends from node along the children with the highest UCT value
+// to a node that has untried moves or ends the game.
+func (p *MCTSPlayer) selectNode(node *mctsNode) *mctsNode {
+	for len(node.untried) == 0 && len(node.children) > 0 {
+		logVisits := math.Log(float64(node.visits))
+		var best *mctsNode
+		bestValue := math.Inf(-1)
+		for _, child := range node.children {
+			value := child.score/float64(child.visits) +
+				p.Exploration*math.Sqrt(logVisits/float64(child.visits))
+			if value > bestValue {
+				best, bestValue = child, value
+			}
+		}
+		node = best
+	}
+	return node
+}
+
+// expand adds a child to node by playing one of its untried moves at random.
+func (p *MCTSPlayer) expand(node *mctsNode) *mctsNode {
+	i := p.rng.Intn(len(node.untried))
+	move := node.untried[i]
+	node.untried[i] = node.untried[len(node.untried)-1]
+	node.untried = node.untried[:len(node.untried)-1]
+	field := node.field.Opponent()
+	next, outcome, _ := node.board.Play(move, field)
+	child := &mctsNode{
+		parent:  node,
+		board:   next,
+		move:    move,
+		field:   field,
+		outcome: outcome,
+	}
+	if outcome == board.Undecided {
+		child.untried = next.ValidMoves()
+	}
+	node.children = append(node.children, child)
+	return child
+}
+
+// playout plays random moves on board b, starting with the player with the
+// given field, until the game is decided, and returns the outcome.
+func (p *MCTSPlayer) playout(b *board.Board, field board.Field) board.Outcome {
+	for {
+		next, outcome, err := b.Play(pickRandom(p.rng, b.ValidMoves()), field)
+		if err != nil || outcome != board.Undecided {
+			return outcome
+		}
+		b, field = next, field.Opponent()
+	}
+}
+
+// Field returns the field assigned to the player.
+func (p *MCTSPlayer) Field() board.Field {
+	return p.PlayerField
+}
@@ -0,0 +1,47 @@
+package player
+
+import (
+	"4iar/board"
+	"testing"
+)
+
+func TestMCTSPlayer(t *testing.T) {
+	// the minimax tests are shallow enough for the MCTS player to solve
+	for _, test := range minimaxTests {
+		b, err := board.FromFields(test.fields)
+		if err != nil {
+			t.Fatalf("create board from fields %v: %v", test.fields, err)
+		}
+		p := NewMCTSPlayer(test.field, 5000, 0, 1)
+		got := (*p).Play(b)
+		if got == nil || (*got != test.move && !isWinningMove(b, *got, test.field)) {
+			t.Errorf("expected move %d on board \n%v\n, got %v", test.move, b, got)
+		}
+	}
+}
+
+// isWinningMove checks whether move wins the game for the player with the
+// given field within three plies, whatever the opponent plays.
+func isWinningMove(b *board.Board, move board.Move, field board.Field) bool {
+	next, outcome, err := b.Play(move, field)
+	if err != nil {
+		return false
+	}
+	if outcome == board.Outcome(field) {
+		return true
+	}
+	for _, reply := range next.ValidMoves() {
+		after, _, _ := next.Play(reply, field.Opponent())
+		won := false
+		for _, m := range after.ValidMoves() {
+			if _, o, _ := after.Play(m, field); o == board.Outcome(field) {
+				won = true
+				break
+			}
+		}
+		if !won {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package player
+
+import (
+	"4iar/board"
+	"4iar/search"
+	"context"
+	"fmt"
+)
+
+// MinimaxPlayer is a player that looks ahead a fixed number of plies (moves of
+// either player) using the Minimax algorithm. Positions that are not decided
+// within that depth are scored using Evaluate, or as neutral, if it is nil.
+type MinimaxPlayer struct {
+	PlayerField board.Field
+	Depth       int
+	Evaluate    search.Evaluator
+}
+
+// NewMinimaxPlayer creates a new minimax player searching depth plies ahead,
+// scoring undecided positions at that depth using evaluate, which may be nil.
+// A depth less than one is treated as one.
+func NewMinimaxPlayer(field board.Field, depth int, evaluate search.Evaluator) *Player {
+	if depth < 1 {
+		depth = 1
+	}
+	minimaxPlayer := MinimaxPlayer{field, depth, evaluate}
+	p := Player(&minimaxPlayer)
+	return &p
+}
+
+// Play picks the move with the best Minimax score. Ties between equally scored
+// moves are broken in favour of the move closest to the center column, and
+// then of the leftmost one.
+func (p *MinimaxPlayer) Play(b *board.Board) *board.Move {
+	return play(p, b)
+}
+
+// minimaxCheckInterval is the number of nodes searched between checks whether
+// the search has been cancelled.
+const minimaxCheckInterval = 64
+
+// PlayContext works like Play, but reports failures as errors. The search is
+// stopped as soon as ctx is done, in which case ctx.Err() is returned.
+func (p *MinimaxPlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	candidates := board.CenterFirst(b.ValidMoves(), b.Cols())
+	if len(candidates) == 0 {
+		return 0, ErrorNoMove
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	s := minimaxSearch{MinimaxPlayer: p, ctx: ctx}
+	best := -1
+	bestScore := 0
+	for i, candidate := range candidates {
+		next, outcome, err := b.Play(candidate, p.PlayerField)
+		if err != nil {
+			return 0, fmt.Errorf("play move %v on board %v: %w", candidate, b, err)
+		}
+		score := s.minimax(next, outcome, 1)
+		if s.err != nil {
+			return 0, s.err
+		}
+		if best == -1 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return candidates[best], nil
+}
+
+// minimaxSearch is the state of a single search of a minimax player.
+type minimaxSearch struct {
+	*MinimaxPlayer
+	ctx   context.Context
+	nodes int
+	// err is the error of ctx, once the search has been stopped.
+	err error
+}
+
+// minimax returns the score of the board b, which has been reached after ply
+// plies with the given outcome, from the perspective of the player. Once the
+// search has been stopped, 0 is returned.
+func (s *minimaxSearch) minimax(b *board.Board, outcome board.Outcome, ply int) int {
+	s.nodes++
+	if s.nodes%minimaxCheckInterval == 0 && s.err == nil {
+		s.err = s.ctx.Err()
+	}
+	if s.err != nil {
+		return 0
+	}
+	switch outcome {
+	case board.Outcome(s.PlayerField):
+		return search.WinScore - ply
+	case board.Outcome(s.PlayerField.Opponent()):
+		return -search.WinScore + ply
+	case board.Tie:
+		return 0
+	}
+	if ply >= s.Depth {
+		if s.Evaluate == nil {
+			return 0
+		}
+		return s.Evaluate(b, s.PlayerField)
+	}
+	maximizing := ply%2 == 0
+	active := s.PlayerField
+	if !maximizing {
+		active = active.Opponent()
+	}
+	var bestScore int
+	for i, move := range b.ValidMoves() {
+		next, nextOutcome, err := b.Play(move, active)
+		if err != nil {
+			continue
+		}
+		score := s.minimax(next, nextOutcome, ply+1)
+		if i == 0 || (maximizing && score > bestScore) || (!maximizing && score < bestScore) {
+			bestScore = score
+		}
+	}
+	return bestScore
+}
+
+// Field returns the field assigned to the player.
+func (p *MinimaxPlayer) Field() board.Field {
+	return p.PlayerField
+}
@@ -0,0 +1,86 @@
+package player
+
+import (
+	"4iar/board"
+	"context"
+	"testing"
+	"time"
+)
+
+var minimaxTests = []struct {
+	fields [][]board.Field
+	field  board.Field
+	depth  int
+	move   board.Move
+}{
+	{
+		// win immediately
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{2, 2, 2, 0, 0, 0, 0},
+			{1, 1, 1, 0, 0, 0, 0},
+		},
+		board.PlayerOne,
+		4,
+		3,
+	},
+	{
+		// block the opponent's vertical row
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 1},
+			{0, 0, 0, 0, 0, 2, 1},
+			{0, 0, 0, 0, 2, 2, 1},
+		},
+		board.PlayerTwo,
+		2,
+		6,
+	},
+	{
+		// create two threats at once: then both 1 and 5 complete a row
+		[][]board.Field{
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 2, 2, 0, 0, 0},
+			{0, 0, 1, 1, 0, 0, 0},
+		},
+		board.PlayerOne,
+		3,
+		4,
+	},
+}
+
+func TestMinimaxPlayer(t *testing.T) {
+	for _, test := range minimaxTests {
+		b, err := board.FromFields(test.fields)
+		if err != nil {
+			t.Fatalf("create board from fields %v: %v", test.fields, err)
+		}
+		p := NewMinimaxPlayer(test.field, test.depth, nil)
+		got := (*p).Play(b)
+		if got == nil || *got != test.move {
+			t.Errorf("expected move %d on board \n%v\n, got %v", test.move, b, got)
+		}
+	}
+}
+
+func TestMinimaxPlayerCancelled(t *testing.T) {
+	p := NewMinimaxPlayer(board.PlayerOne, 20, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := (*p).(ContextPlayer).PlayContext(ctx, board.NewBoard())
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected search to stop at the deadline, took %v", elapsed)
+	}
+}
@@ -2,7 +2,73 @@
 // different player implementations.
 package player
 
-import "4iar/board"
+import (
+	"4iar/board"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// ErrorNoMove indicates that a player didn't play a move, e.g. because there
+// was no valid move left, or a human player quit the game.
+var ErrorNoMove = errors.New("no move played")
+
+// PanicError is the error reported for a player that panicked while picking a
+// move.
+type PanicError struct {
+	// Value is the value the player panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// SafePlay calls the PlayContext method of p, recovering from a panic, which
+// is reported as a *PanicError.
+func SafePlay(ctx context.Context, p ContextPlayer, b *board.Board) (move board.Move, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{r, debug.Stack()}
+		}
+	}()
+	return p.PlayContext(ctx, b)
+}
+
+// maxSafetyMargin is the maximum time reserved for returning a move found
+// before the deadline of a context.
+const maxSafetyMargin = 50 * time.Millisecond
+
+// withSafetyMargin returns a copy of ctx with a deadline shortly before the
+// one of ctx, if it has one, so that a player searching until the copy is done
+// still has time to return its move. A tenth of the time left is reserved, but
+// at most maxSafetyMargin.
+func withSafetyMargin(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	margin := time.Until(deadline) / 10
+	if margin > maxSafetyMargin {
+		margin = maxSafetyMargin
+	}
+	return context.WithDeadline(ctx, deadline.Add(-margin))
+}
+
+// expired returns true if ctx is done or its deadline has passed, which is
+// checked directly, as the timer cancelling ctx may run late on a busy
+// machine.
+func expired(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
 
 // Player describes a player that is able to play a move on the given board.
 type Player interface {
@@ -12,3 +78,72 @@ type Player interface {
 	// Field returns the field assigned to the player.
 	Field() board.Field
 }
+
+// ContextPlayer describes a player that is able to play a move on the given
+// board, to take the time left for a move into account, and to report why no
+// move could be played.
+type ContextPlayer interface {
+	// PlayContext returns a move with the given field to be applied to the
+	// board, which should be picked before ctx is done, or an error if no
+	// move could be picked.
+	PlayContext(ctx context.Context, b *board.Board) (board.Move, error)
+
+	// Field returns the field assigned to the player.
+	Field() board.Field
+}
+
+// Adapt returns p as a ContextPlayer. If p doesn't implement ContextPlayer
+// itself, its Play method is called in the background: if ctx is done before
+// Play returns, ctx.Err() is returned, a nil move is reported as ErrorNoMove,
+// and a panic as a *PanicError.
+func Adapt(p *Player) ContextPlayer {
+	if cp, ok := (*p).(ContextPlayer); ok {
+		return cp
+	}
+	return adapter{*p}
+}
+
+type adapter struct {
+	Player
+}
+
+func (a adapter) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	type result struct {
+		move *board.Move
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				results <- result{nil, &PanicError{r, debug.Stack()}}
+			}
+		}()
+		results <- result{a.Play(b), nil}
+	}()
+	select {
+	case r := <-results:
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.move == nil {
+			return 0, ErrorNoMove
+		}
+		return *r.move, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// play calls the PlayContext method of p without a deadline, and returns the
+// move as Play does, i.e. nil if no move could be picked.
+func play(p ContextPlayer, b *board.Board) *board.Move {
+	move, err := p.PlayContext(context.Background(), b)
+	if err != nil {
+		return nil
+	}
+	return &move
+}
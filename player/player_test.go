@@ -0,0 +1,45 @@
+package player
+
+import (
+	"4iar/board"
+	"context"
+	"testing"
+	"time"
+)
+
+// stuckPlayer never returns from Play until it is released.
+type stuckPlayer struct {
+	release chan struct{}
+}
+
+func (p *stuckPlayer) Play(b *board.Board) *board.Move {
+	<-p.release
+	return nil
+}
+
+func (p *stuckPlayer) Field() board.Field {
+	return board.PlayerOne
+}
+
+func TestAdapt(t *testing.T) {
+	b := board.NewBoard()
+	random := NewRandomPlayer(board.PlayerOne, 1)
+	move, err := Adapt(random).PlayContext(context.Background(), b)
+	if err != nil || !board.Contains(b.ValidMoves(), move) {
+		t.Errorf("expected valid move, got %d (error %v)", move, err)
+	}
+
+	stuck := &stuckPlayer{make(chan struct{})}
+	defer close(stuck.release)
+	var p Player = stuck
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := Adapt(&p).PlayContext(ctx, b); err != context.DeadlineExceeded {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	alphaBeta := NewAlphaBetaPlayer(board.PlayerOne, 1, nil)
+	if _, ok := Adapt(alphaBeta).(*AlphaBetaPlayer); !ok {
+		t.Errorf("expected context player to be returned as is")
+	}
+}
@@ -3,19 +3,19 @@ package player
 import (
 	"4iar/board"
 	"math/rand"
-	"time"
 )
 
 // RandomPlayer is a player that plays random moves.
 type RandomPlayer struct {
 	PlayerField board.Field
+	rng         *rand.Rand
 }
 
-// NewRandomPlayer creates a new random player.
-func NewRandomPlayer(field board.Field) *Player {
-	randomPlayer := RandomPlayer{field}
+// NewRandomPlayer creates a new random player, which picks its moves using a
+// random number generator with the given seed.
+func NewRandomPlayer(field board.Field, seed int64) *Player {
+	randomPlayer := RandomPlayer{field, rand.New(rand.NewSource(seed))}
 	p := Player(&randomPlayer)
-	rand.Seed(time.Now().Unix())
 	return &p
 }
 
@@ -28,8 +28,20 @@ func (p *RandomPlayer) Play(b *board.Board) *board.Move {
 	if len(candidates) == 1 {
 		return &candidates[0]
 	}
-	pick := rand.Intn(len(candidates))
-	return &candidates[pick]
+	pick := pickRandom(p.rng, candidates)
+	return &pick
+}
+
+// pickRandom picks one of the candidates at random using rng.
+func pickRandom(rng *rand.Rand, candidates []board.Move) board.Move {
+	return candidates[rng.Intn(len(candidates))]
+}
+
+// DeriveSeed returns the n-th seed derived from the master seed, so that
+// players created with the derived seeds play independently of each other, but
+// reproducibly given the master seed.
+func DeriveSeed(master int64, n int) int64 {
+	return int64(board.SplitMix64(uint64(master), n))
 }
 
 // Field returns the field assigned to the player.
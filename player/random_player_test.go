@@ -0,0 +1,48 @@
+package player
+
+import (
+	"4iar/board"
+	"4iar/notation"
+	"testing"
+)
+
+// playOut lets both players play on an empty board until it is full or one of
+// them wins, and returns the moves played.
+func playOut(t *testing.T, one, two *Player) []board.Move {
+	b := board.NewBoard()
+	players := []*Player{one, two}
+	var moves []board.Move
+	for i := 0; ; i++ {
+		p := *players[i%2]
+		move := p.Play(b)
+		if move == nil {
+			return moves
+		}
+		moves = append(moves, *move)
+		next, outcome, err := b.Play(*move, p.Field())
+		if err != nil {
+			t.Fatalf("play move %d: %v", *move, err)
+		}
+		if outcome != board.Undecided {
+			return moves
+		}
+		b = next
+	}
+}
+
+func TestSeededPlayers(t *testing.T) {
+	for name, spawn := range map[string]func(board.Field, int64) *Player{
+		"random":  NewRandomPlayer,
+		"winning": NewWinningMovePlayer,
+	} {
+		seedOne, seedTwo := DeriveSeed(42, 0), DeriveSeed(42, 1)
+		if seedOne == seedTwo {
+			t.Fatalf("expected distinct derived seeds, got %d twice", seedOne)
+		}
+		first := playOut(t, spawn(board.PlayerOne, seedOne), spawn(board.PlayerTwo, seedTwo))
+		second := playOut(t, spawn(board.PlayerOne, seedOne), spawn(board.PlayerTwo, seedTwo))
+		if notation.Format(first) != notation.Format(second) {
+			t.Errorf("expected %s players with the same seeds to play the same moves", name)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package player
+
+import (
+	"4iar/board"
+	"4iar/evaluation"
+	"sort"
+)
+
+// Registry maps names to functions creating the available kinds of players,
+// with their default settings. Players making random choices use the given
+// seed, the others ignore it.
+var Registry = map[string]func(board.Field, int64) *Player{
+	"human": func(f board.Field, seed int64) *Player {
+		return NewHumanPlayer(f)
+	},
+	"random":  NewRandomPlayer,
+	"winning": NewWinningMovePlayer,
+	"greedy": func(f board.Field, seed int64) *Player {
+		return NewGreedyPlayer(f)
+	},
+	"minimax": func(f board.Field, seed int64) *Player {
+		return NewMinimaxPlayer(f, 4, evaluation.Score)
+	},
+	"alphabeta": func(f board.Field, seed int64) *Player {
+		return NewAlphaBetaPlayer(f, 8, evaluation.Score)
+	},
+	"mcts": func(f board.Field, seed int64) *Player {
+		return NewMCTSPlayer(f, 5000, 0, seed)
+	},
+}
+
+// Names returns the names of the players in the Registry in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(Registry))
+	for name := range Registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,46 +2,51 @@ package player
 
 import (
 	"4iar/board"
-	"log"
+	"context"
+	"fmt"
 	"math/rand"
-	"time"
 )
 
 // WinningMovePlayer is a player smart enough to detect and play winning moves.
 type WinningMovePlayer struct {
 	PlayerField board.Field
+	rng         *rand.Rand
 }
 
-// NewWinningMovePlayer creates a new winning move player.
-func NewWinningMovePlayer(field board.Field) *Player {
-	winningMovePlayer := WinningMovePlayer{field}
+// NewWinningMovePlayer creates a new winning move player, which picks its
+// moves using a random number generator with the given seed if there's no
+// winning move.
+func NewWinningMovePlayer(field board.Field, seed int64) *Player {
+	winningMovePlayer := WinningMovePlayer{field, rand.New(rand.NewSource(seed))}
 	p := Player(&winningMovePlayer)
-	rand.Seed(time.Now().Unix())
 	return &p
 }
 
 // Play tries to find a winning move, or picks a random move, if no winning
 // move is available.
 func (p *WinningMovePlayer) Play(b *board.Board) *board.Move {
+	return play(p, b)
+}
+
+// PlayContext works like Play, but reports failures as errors.
+func (p *WinningMovePlayer) PlayContext(ctx context.Context, b *board.Board) (board.Move, error) {
 	candidates := b.ValidMoves()
 	if len(candidates) == 0 {
-		return nil
+		return 0, ErrorNoMove
 	}
 	if len(candidates) == 1 {
-		return &candidates[0]
+		return candidates[0], nil
 	}
 	for _, candidate := range candidates {
 		_, outcome, err := b.Play(candidate, p.PlayerField)
 		if err != nil {
-			log.Printf("play move %v on board %v: %v", candidate, b, err)
-			return nil
+			return 0, fmt.Errorf("play move %v on board %v: %w", candidate, b, err)
 		}
 		if int(outcome) == int(p.PlayerField) {
-			return &candidate
+			return candidate, nil
 		}
 	}
-	pick := rand.Intn(len(candidates))
-	return &candidates[pick]
+	return pickRandom(p.rng, candidates), nil
 }
 
 // Field returns the field assigned to the player.
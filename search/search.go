@@ -0,0 +1,280 @@
+// Package search implements a game tree search for Four in a Row, using
+// negamax with alpha-beta pruning and a transposition table.
+package search
+
+import (
+	"4iar/board"
+	"context"
+	"time"
+)
+
+const (
+	// WinScore is the score of a won position, which is reduced by the number
+	// of plies needed to reach it, so that quicker wins are preferred.
+	WinScore = 1000000
+	// DefaultTableSize is the number of transposition table entries used if no
+	// size is given.
+	DefaultTableSize = 1 << 16
+
+	maxPlies = board.MaxFields
+	infinity = WinScore + 1
+
+	// checkInterval is the number of nodes searched between checks whether
+	// the search has been cancelled.
+	checkInterval = 64
+)
+
+// Evaluator scores a position that has not been decided yet from the
+// perspective of the player with the given field. Positive scores favour that
+// player. Scores must be less than WinScore-MaxFields in absolute value.
+type Evaluator func(b *board.Board, field board.Field) int
+
+// Result is the outcome of a search.
+type Result struct {
+	// Move is the best move found.
+	Move board.Move
+	// Score is the score of the best move from the perspective of the player
+	// to move.
+	Score int
+	// Nodes is the number of positions visited.
+	Nodes int
+	// PV is the principal variation, i.e. the sequence of moves expected to be
+	// played by both players, starting with Move.
+	PV []board.Move
+}
+
+// Engine searches positions to a fixed depth, reusing the results of previous
+// searches stored in its transposition table. An engine is not safe for
+// concurrent use.
+type Engine struct {
+	// Depth is the number of plies searched.
+	Depth int
+	// Evaluate scores the positions at the search horizon; if nil, such
+	// positions are scored as neutral.
+	Evaluate Evaluator
+
+	table   table
+	nodes   int
+	ctx     context.Context
+	aborted bool
+	// deadline is the deadline of ctx, if it has one, which is checked
+	// directly, as the timer cancelling ctx may run late on a busy machine.
+	deadline    time.Time
+	hasDeadline bool
+}
+
+// NewEngine creates a new engine searching depth plies ahead, scoring the
+// positions at that depth using evaluate, with a transposition table of
+// tableSize entries (DefaultTableSize, if less than one).
+func NewEngine(depth int, evaluate Evaluator, tableSize int) *Engine {
+	if depth < 1 {
+		depth = 1
+	}
+	if tableSize < 1 {
+		tableSize = DefaultTableSize
+	}
+	return &Engine{
+		Depth:    depth,
+		Evaluate: evaluate,
+		table:    newTable(tableSize),
+	}
+}
+
+// Search finds the best move for the player with the given field on board b by
+// iterative deepening up to the engine's depth. If there is no valid move,
+// the result's PV is empty.
+func (e *Engine) Search(b *board.Board, field board.Field) Result {
+	return e.SearchContext(context.Background(), b, field)
+}
+
+// SearchContext works like Search, but stops searching as soon as ctx is done,
+// e.g. because its deadline has passed. The result of the deepest search
+// completed is returned then, or the move closest to the center column, if
+// not even a search of depth one was completed.
+func (e *Engine) SearchContext(ctx context.Context, b *board.Board, field board.Field) Result {
+	e.nodes = 0
+	e.ctx = ctx
+	e.aborted = false
+	e.deadline, e.hasDeadline = ctx.Deadline()
+	defer func() {
+		e.ctx = nil
+	}()
+	var result Result
+	validMoves := b.ValidMoves()
+	if len(validMoves) == 0 {
+		return result
+	}
+	result.Move = order(validMoves, b.Cols(), nil)[0]
+	result.PV = []board.Move{result.Move}
+	completed := false
+	for depth := 1; depth <= e.Depth; depth++ {
+		score := e.negamax(b, field, depth, 0, -infinity, infinity)
+		if e.aborted {
+			break
+		}
+		completed = true
+		result.Score = score
+		if entry, ok := e.table.get(b.Hash()); ok {
+			result.Move = entry.move
+		}
+		if result.Score >= WinScore-maxPlies || result.Score <= -WinScore+maxPlies {
+			// the outcome is known, searching deeper won't change it
+			break
+		}
+	}
+	result.Nodes = e.nodes
+	if completed {
+		result.PV = e.principalVariation(b, field)
+	}
+	return result
+}
+
+// done returns true if the search must be stopped, because ctx is done or its
+// deadline has passed.
+func (e *Engine) done() bool {
+	return e.ctx.Err() != nil || (e.hasDeadline && !time.Now().Before(e.deadline))
+}
+
+// negamax returns the score of board b for the player with the given field to
+// move, searching depth plies ahead of the current ply.
+func (e *Engine) negamax(b *board.Board, field board.Field, depth, ply, alpha, beta int) int {
+	e.nodes++
+	if e.nodes%checkInterval == 0 && e.done() {
+		e.aborted = true
+	}
+	if e.aborted {
+		return 0
+	}
+	if depth == 0 {
+		if e.Evaluate == nil {
+			return 0
+		}
+		return e.Evaluate(b, field)
+	}
+	alphaOrig := alpha
+	hash := b.Hash()
+	var hashMove *board.Move
+	if entry, ok := e.table.get(hash); ok {
+		hashMove = &entry.move
+		if entry.depth >= depth {
+			score := fromTable(entry.score, ply)
+			switch {
+			case entry.bound == exact:
+				return score
+			case entry.bound == lower && score > alpha:
+				alpha = score
+			case entry.bound == upper && score < beta:
+				beta = score
+			}
+			if alpha >= beta {
+				return score
+			}
+		}
+	}
+	bestScore := -infinity
+	var bestMove board.Move
+	for _, move := range order(b.ValidMoves(), b.Cols(), hashMove) {
+		next, outcome, err := b.Play(move, field)
+		if err != nil {
+			continue
+		}
+		var score int
+		switch outcome {
+		case board.Outcome(field):
+			score = WinScore - (ply + 1)
+		case board.Tie:
+			score = 0
+		default:
+			score = -e.negamax(next, field.Opponent(), depth-1, ply+1, -beta, -alpha)
+			if e.aborted {
+				return 0
+			}
+		}
+		if score > bestScore {
+			bestScore, bestMove = score, move
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	bnd := exact
+	if bestScore <= alphaOrig {
+		bnd = upper
+	} else if bestScore >= beta {
+		bnd = lower
+	}
+	e.table.put(entry{
+		hash:  hash,
+		depth: depth,
+		score: toTable(bestScore, ply),
+		bound: bnd,
+		move:  bestMove,
+	})
+	return bestScore
+}
+
+// principalVariation follows the best moves stored in the transposition table,
+// starting from board b with the player with the given field to move.
+func (e *Engine) principalVariation(b *board.Board, field board.Field) []board.Move {
+	pv := make([]board.Move, 0, e.Depth)
+	for len(pv) < e.Depth {
+		entry, ok := e.table.get(b.Hash())
+		if !ok {
+			break
+		}
+		next, outcome, err := b.Play(entry.move, field)
+		if err != nil {
+			break
+		}
+		pv = append(pv, entry.move)
+		if outcome != board.Undecided {
+			break
+		}
+		b, field = next, field.Opponent()
+	}
+	return pv
+}
+
+// order returns the moves with the hash move first, if given, and the other
+// moves by their distance to the center column of a board with cols columns.
+func order(moves []board.Move, cols int, hashMove *board.Move) []board.Move {
+	moves = board.CenterFirst(moves, cols)
+	if hashMove == nil {
+		return moves
+	}
+	for i, m := range moves {
+		if m == *hashMove {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = m
+			break
+		}
+	}
+	return moves
+}
+
+// toTable converts a win or loss score relative to the root into a score
+// relative to the position at ply, which can be stored independently of the
+// path leading to the position.
+func toTable(score, ply int) int {
+	if score >= WinScore-maxPlies {
+		return score + ply
+	}
+	if score <= -WinScore+maxPlies {
+		return score - ply
+	}
+	return score
+}
+
+// fromTable reverses toTable for a position at ply.
+func fromTable(score, ply int) int {
+	if score >= WinScore-maxPlies {
+		return score - ply
+	}
+	if score <= -WinScore+maxPlies {
+		return score + ply
+	}
+	return score
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"4iar/board"
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// negamax is a plain negamax search without pruning to verify the engine's
+// scores against.
+func negamax(b *board.Board, field board.Field, depth, ply int) int {
+	if depth == 0 {
+		return 0
+	}
+	best := -infinity
+	for _, move := range b.ValidMoves() {
+		next, outcome, _ := b.Play(move, field)
+		var score int
+		switch outcome {
+		case board.Outcome(field):
+			score = WinScore - (ply + 1)
+		case board.Tie:
+			score = 0
+		default:
+			score = -negamax(next, field.Opponent(), depth-1, ply+1)
+		}
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// randomPosition plays up to n random moves, stopping before a move that
+// would decide the game, and returns the board and the player to move.
+func randomPosition(rng *rand.Rand, n int) (*board.Board, board.Field) {
+	b := board.NewBoard()
+	field := board.PlayerOne
+	for i := 0; i < n; i++ {
+		moves := b.ValidMoves()
+		next, outcome, _ := b.Play(moves[rng.Intn(len(moves))], field)
+		if outcome != board.Undecided {
+			break
+		}
+		b, field = next, field.Opponent()
+	}
+	return b, field
+}
+
+func TestSearchMatchesNegamax(t *testing.T) {
+	const depth = 5
+	rng := rand.New(rand.NewSource(42))
+	engine := NewEngine(depth, nil, 1<<12)
+	for i := 0; i < 50; i++ {
+		b, field := randomPosition(rng, 8+rng.Intn(20))
+		expected := negamax(b, field, depth, 0)
+		got := engine.Search(b, field)
+		if got.Score != expected {
+			t.Errorf("expected score %d for board \n%v\n, got %d", expected, b, got.Score)
+		}
+		if got.Nodes == 0 {
+			t.Errorf("expected nodes to be counted for board \n%v\n", b)
+		}
+		if len(got.PV) == 0 || got.PV[0] != got.Move {
+			t.Errorf("expected PV %v to start with move %d", got.PV, got.Move)
+		}
+	}
+}
+
+func TestSearchFindsWin(t *testing.T) {
+	b, err := board.FromFields([][]board.Field{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 2, 2, 0, 0, 0},
+		{0, 0, 1, 1, 0, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewEngine(6, nil, 0).Search(b, board.PlayerOne)
+	if got.Score != WinScore-3 {
+		t.Errorf("expected win in 3 plies with score %d, got %d", WinScore-3, got.Score)
+	}
+	if got.Move != 1 && got.Move != 4 {
+		t.Errorf("expected move 1 or 4, got %d", got.Move)
+	}
+	if len(got.PV) != 3 {
+		t.Errorf("expected PV of three moves, got %v", got.PV)
+	}
+}
+
+func TestSearchContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	engine := NewEngine(20, nil, 0)
+	got := engine.SearchContext(ctx, board.NewBoard(), board.PlayerOne)
+	if got.Move != 3 {
+		t.Errorf("expected center move 3 from cancelled search, got %d", got.Move)
+	}
+	if got.Nodes > checkInterval {
+		t.Errorf("expected search to stop after %d nodes, got %d", checkInterval, got.Nodes)
+	}
+}
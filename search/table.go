@@ -0,0 +1,43 @@
+package search
+
+import "4iar/board"
+
+type bound int
+
+const (
+	exact bound = iota
+	lower
+	upper
+)
+
+// entry is a transposition table entry, holding the result of searching a
+// position to the given depth.
+type entry struct {
+	hash  uint64
+	depth int
+	score int
+	bound bound
+	move  board.Move
+	used  bool
+}
+
+// table is a transposition table of fixed size, which replaces entries whose
+// slot is taken by another position.
+type table []entry
+
+func newTable(size int) table {
+	if size < 1 {
+		size = 1
+	}
+	return table(make([]entry, size))
+}
+
+func (t table) get(hash uint64) (entry, bool) {
+	e := t[hash%uint64(len(t))]
+	return e, e.used && e.hash == hash
+}
+
+func (t table) put(e entry) {
+	e.used = true
+	t[e.hash%uint64(len(t))] = e
+}
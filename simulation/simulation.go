@@ -9,32 +9,38 @@ import (
 	"log"
 	"os"
 	"sync"
+	"time"
 )
 
 func main() {
 	numberOfRounds := flag.Int("n", 1, "numbers of rounds to play")
+	seed := flag.Int64("seed", 0, "master seed for all players (0 for a random one)")
 	flag.Parse()
 	if *numberOfRounds < 1 {
 		log.Printf("unable to play %d rounds\n", *numberOfRounds)
 		os.Exit(1)
 	}
-	playerOne := player.NewRandomPlayer(board.PlayerOne)
-	playerTwo := player.NewRandomPlayer(board.PlayerTwo)
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	fmt.Printf("Seed: %d\n", *seed)
 	playerOneWins, playerTwoWins, ties, undecided := 0, 0, 0, 0
 	output := *numberOfRounds == 1
 	ch := make(chan board.Outcome)
 	var wg sync.WaitGroup
 	for i := 0; i < *numberOfRounds; i++ {
+		playerOne := player.NewRandomPlayer(board.PlayerOne, player.DeriveSeed(*seed, 2*i))
+		playerTwo := player.NewRandomPlayer(board.PlayerTwo, player.DeriveSeed(*seed, 2*i+1))
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			duel := game.NewGame(playerOne, playerTwo)
-			outcome, err := duel.Play(output)
+			record, err := duel.Play(output)
 			if err != nil {
 				log.Printf("play duel: %v\n", err)
 				return
 			}
-			ch <- outcome
+			ch <- record.Outcome
 		}()
 	}
 	go func() {
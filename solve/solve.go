@@ -0,0 +1,43 @@
+package main
+
+import (
+	"4iar/notation"
+	"4iar/solver"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	tableSize := flag.Int("t", solver.DefaultTableSize, "number of transposition table entries")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [moves]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	moves := strings.Join(flag.Args(), "")
+	b, field, err := notation.Parse(moves)
+	if err != nil {
+		log.Fatalf("parse moves '%s': %v", moves, err)
+	}
+	fmt.Print(b)
+	fmt.Printf("Player %d to move\n\n", field)
+	s := solver.New(*tableSize)
+	start := time.Now()
+	values, err := s.Analyze(b, field)
+	if err != nil {
+		log.Fatalf("analyze moves '%s': %v", moves, err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Column\tScore\tResult\tPlies\t")
+	for _, mv := range values {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t\n", mv.Move+1, mv.Value.Score, mv.Value.Result,
+			mv.Value.Distance)
+	}
+	tw.Flush()
+	fmt.Printf("\nSolved in %v\n", time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,158 @@
+package solver
+
+import (
+	"4iar/board"
+	"math/bits"
+)
+
+// position is a bitboard representation of a board from the perspective of
+// the player to move. Every column is represented by rows+1 bits, the field
+// in column c and row r (counted from the bottom) being the bit c*(rows+1)+r.
+// The additional bit on top of each column is never set, which separates the
+// columns, so that rows can be detected by shifting the masks.
+type position struct {
+	rows    int
+	cols    int
+	goal    int
+	current uint64 // fields of the player to move
+	mask    uint64 // fields of both players
+	moves   int
+	bottom  uint64 // the bottom field of every column
+	fields  uint64 // all fields of the board
+}
+
+// fromBoard creates a position from board b with the player with the given
+// field to move. ErrorUnsupportedBoard is returned if the board doesn't fit
+// into a 64 bit mask including the separator bits.
+func fromBoard(b *board.Board, field board.Field) (*position, error) {
+	rows, cols := b.Rows(), b.Cols()
+	if (rows+1)*cols > 64 {
+		return nil, ErrorUnsupportedBoard
+	}
+	p := &position{rows: rows, cols: cols, goal: b.Goal()}
+	for c := 0; c < cols; c++ {
+		p.bottom |= 1 << uint(c*(rows+1))
+	}
+	p.fields = p.bottom * (1<<uint(rows) - 1)
+	for c := 0; c < cols; c++ {
+		for r := 0; r < rows; r++ {
+			f := b.Field(rows-1-r, c)
+			if f == board.Empty {
+				continue
+			}
+			bit := uint64(1) << uint(c*(rows+1)+r)
+			p.mask |= bit
+			if f == field {
+				p.current |= bit
+			}
+			p.moves++
+		}
+	}
+	return p, nil
+}
+
+// size returns the number of fields of the board.
+func (p *position) size() int {
+	return p.rows * p.cols
+}
+
+// key returns a unique key of the position: with the separator bits, the sum
+// of the masks differs for all positions. The bottom fields are added, so
+// that no key is zero.
+func (p *position) key() uint64 {
+	return p.current + p.mask + p.bottom
+}
+
+// possible returns the mask of the fields that can be played next.
+func (p *position) possible() uint64 {
+	return (p.mask + p.bottom) & p.fields
+}
+
+// column returns the mask of all fields in column col.
+func (p *position) column(col int) uint64 {
+	return (1<<uint(p.rows) - 1) << uint(col*(p.rows+1))
+}
+
+// play plays the field move, which must be one of the possible fields, for the
+// player to move, who becomes the opponent.
+func (p *position) play(move uint64) {
+	p.current ^= p.mask
+	p.mask |= move
+	p.moves++
+}
+
+// canWinNext checks whether the player to move can win with the next move.
+func (p *position) canWinNext() bool {
+	return p.winning(p.current)&p.possible() != 0
+}
+
+// possibleNonLosing returns the mask of the possible fields that don't let the
+// opponent win with the next move. The mask is empty if the opponent can win
+// no matter what is played. The player to move must not be able to win with
+// the next move.
+func (p *position) possibleNonLosing() uint64 {
+	possible := p.possible()
+	opponentWin := p.winning(p.current ^ p.mask)
+	forced := possible & opponentWin
+	if forced != 0 {
+		if forced&(forced-1) != 0 {
+			// more than one winning field of the opponent
+			return 0
+		}
+		possible = forced
+	}
+	// don't play below a winning field of the opponent
+	return possible &^ (opponentWin >> 1)
+}
+
+// winning returns the mask of the empty fields that complete a row of the
+// board's goal length of fields in player.
+func (p *position) winning(player uint64) uint64 {
+	var r uint64
+	for _, s := range p.shifts() {
+		for k := 0; k < p.goal; k++ {
+			// the empty field is at index k of the row
+			m := ^uint64(0)
+			for j := 0; j < p.goal; j++ {
+				if j == k {
+					continue
+				}
+				d := (j - k) * s
+				if d > 0 {
+					m &= player >> uint(d)
+				} else {
+					m &= player << uint(-d)
+				}
+			}
+			r |= m
+		}
+	}
+	return r & (p.fields ^ p.mask)
+}
+
+// aligned checks whether the fields in player contain a row of the board's
+// goal length.
+func aligned(p *position, player uint64) bool {
+	for _, s := range p.shifts() {
+		m := player
+		for i := 1; i < p.goal; i++ {
+			m &= player >> uint(i*s)
+		}
+		if m != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// shifts returns the distances of neighbouring fields in the vertical,
+// horizontal and both diagonal directions.
+func (p *position) shifts() [4]int {
+	return [4]int{1, p.rows + 1, p.rows, p.rows + 2}
+}
+
+// moveScore returns the number of winning fields the player to move has after
+// playing move, used to try promising moves first.
+func (p *position) moveScore(move uint64) int {
+	return bits.OnesCount64(p.winning(p.current | move))
+}
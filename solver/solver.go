@@ -0,0 +1,268 @@
+// Package solver computes the game-theoretic value of positions, i.e. the
+// outcome under perfect play of both players, using bitboards, negamax with
+// alpha-beta pruning, null-window search and a transposition table.
+package solver
+
+import (
+	"4iar/board"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrorUnsupportedBoard indicates a board that is too large for the
+	// solver's bitboard representation.
+	ErrorUnsupportedBoard = errors.New("board too large for solver")
+	// ErrorGameOver indicates a position in which a player already won.
+	ErrorGameOver = errors.New("game is already over")
+)
+
+// DefaultTableSize is the number of transposition table entries used if no
+// size is given.
+const DefaultTableSize = 1<<23 + 9
+
+// Result is the game-theoretic result of a position for the player to move.
+type Result int
+
+const (
+	// Draw is the result of a position that neither player can win.
+	Draw Result = iota
+	// Win is the result of a position the player to move can win.
+	Win
+	// Loss is the result of a position the opponent can win.
+	Loss
+)
+
+func (r Result) String() string {
+	switch r {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	}
+	return "draw"
+}
+
+// Value is the value of a position for the player to move.
+type Value struct {
+	// Score is positive for a win, negative for a loss, and zero for a draw.
+	// The earlier the game is won, the higher the absolute value: a score of
+	// one means a win with the last field of the winner.
+	Score int
+	// Result is the result indicated by the score.
+	Result Result
+	// Distance is the number of plies until the game ends under perfect play,
+	// with the winner trying to win as quickly as possible, and the loser
+	// trying to lose as slowly as possible.
+	Distance int
+}
+
+func (v Value) String() string {
+	return fmt.Sprintf("%s in %d (score %d)", v.Result, v.Distance, v.Score)
+}
+
+// MoveValue is the value of a move for the player playing it.
+type MoveValue struct {
+	Move  board.Move
+	Value Value
+}
+
+// Solver solves positions, keeping the transposition table between calls. A
+// solver is not safe for concurrent use.
+type Solver struct {
+	table   table
+	nodes   int
+	columns []board.Move
+}
+
+// New creates a new solver with a transposition table of tableSize entries
+// (DefaultTableSize, if less than one).
+func New(tableSize int) *Solver {
+	if tableSize < 1 {
+		tableSize = DefaultTableSize
+	}
+	return &Solver{table: newTable(tableSize)}
+}
+
+// Nodes returns the number of positions visited by the last call to Solve or
+// Analyze.
+func (s *Solver) Nodes() int {
+	return s.nodes
+}
+
+// Solve returns the value of board b for the player with the given field to
+// move.
+func (s *Solver) Solve(b *board.Board, field board.Field) (Value, error) {
+	p, err := s.prepare(b, field)
+	if err != nil {
+		return Value{}, err
+	}
+	return value(p, s.solve(p)), nil
+}
+
+// Analyze returns the value of every valid move on board b for the player with
+// the given field to move, ordered by column.
+func (s *Solver) Analyze(b *board.Board, field board.Field) ([]MoveValue, error) {
+	p, err := s.prepare(b, field)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]MoveValue, 0, p.cols)
+	for col := 0; col < p.cols; col++ {
+		move := p.possible() & p.column(col)
+		if move == 0 {
+			continue
+		}
+		var score int
+		if p.winning(p.current)&move != 0 {
+			score = (p.size() + 1 - p.moves) / 2
+		} else {
+			next := *p
+			next.play(move)
+			score = -s.solve(&next)
+		}
+		values = append(values, MoveValue{board.Move(col), value(p, score)})
+	}
+	return values, nil
+}
+
+func (s *Solver) prepare(b *board.Board, field board.Field) (*position, error) {
+	s.nodes = 0
+	p, err := fromBoard(b, field)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]board.Move, p.cols)
+	for col := range columns {
+		columns[col] = board.Move(col)
+	}
+	s.columns = board.CenterFirst(columns, p.cols)
+	if aligned(p, p.current) || aligned(p, p.current^p.mask) {
+		return nil, ErrorGameOver
+	}
+	return p, nil
+}
+
+// solve returns the score of position p by narrowing down the range of
+// possible scores with null-window searches.
+func (s *Solver) solve(p *position) int {
+	if p.canWinNext() {
+		return (p.size() + 1 - p.moves) / 2
+	}
+	min := -(p.size() - p.moves) / 2
+	max := (p.size() + 1 - p.moves) / 2
+	for min < max {
+		med := min + (max-min)/2
+		if med <= 0 && min/2 < med {
+			med = min / 2
+		} else if med >= 0 && max/2 > med {
+			med = max / 2
+		}
+		// is the score greater than med?
+		r := s.negamax(p, med, med+1)
+		if r <= med {
+			max = r
+		} else {
+			min = r
+		}
+	}
+	return min
+}
+
+// negamax returns the score of position p, if it is in the window of alpha and
+// beta, or a bound of the score outside of the window. The player to move must
+// not be able to win with the next move.
+func (s *Solver) negamax(p *position, alpha, beta int) int {
+	s.nodes++
+	next := p.possibleNonLosing()
+	if next == 0 {
+		return -(p.size() - p.moves) / 2
+	}
+	if p.moves >= p.size()-2 {
+		return 0
+	}
+	if min := -(p.size() - 2 - p.moves) / 2; alpha < min {
+		alpha = min
+		if alpha >= beta {
+			return alpha
+		}
+	}
+	max := (p.size() - 1 - p.moves) / 2
+	if upper, ok := s.table.get(p.key()); ok {
+		max = upper
+	}
+	if beta > max {
+		beta = max
+		if alpha >= beta {
+			return beta
+		}
+	}
+	for _, move := range s.order(p, next) {
+		child := *p
+		child.play(move)
+		score := -s.negamax(&child, -beta, -alpha)
+		if score >= beta {
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	s.table.put(p.key(), alpha)
+	return alpha
+}
+
+// order returns the fields of moves, ordered by the number of winning fields
+// they create, and by their distance to the center column.
+func (s *Solver) order(p *position, moves uint64) []uint64 {
+	type candidate struct {
+		move  uint64
+		score int
+	}
+	candidates := make([]candidate, 0, p.cols)
+	for _, col := range s.columns {
+		move := moves & p.column(int(col))
+		if move != 0 {
+			candidates = append(candidates, candidate{move, p.moveScore(move)})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	ordered := make([]uint64, len(candidates))
+	for i, c := range candidates {
+		ordered[i] = c.move
+	}
+	return ordered
+}
+
+// value converts the score of a position derived from p into a value, where
+// p has the player to move for whom the score is given.
+func value(p *position, score int) Value {
+	v := Value{Score: score}
+	switch {
+	case score > 0:
+		// the player to move wins on a move played after n moves, where n
+		// has the same parity as the current number of moves
+		v.Result = Win
+		n := p.size() + 1 - 2*score
+		if (n-p.moves)%2 != 0 {
+			n--
+		}
+		v.Distance = n - p.moves + 1
+	case score < 0:
+		// the opponent wins on a move played after n moves, where n has the
+		// opposite parity of the current number of moves
+		v.Result = Loss
+		n := p.size() + 1 + 2*score
+		if (n-p.moves)%2 == 0 {
+			n--
+		}
+		v.Distance = n - p.moves + 1
+	default:
+		v.Result = Draw
+		v.Distance = p.size() - p.moves
+	}
+	return v
+}
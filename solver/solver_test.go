@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"4iar/board"
+	"4iar/notation"
+	"4iar/search"
+	"math/rand"
+	"testing"
+)
+
+// randomPosition plays n random moves, none of them deciding the game, and
+// returns the board and the player to move.
+func randomPosition(rng *rand.Rand, n int) (*board.Board, board.Field) {
+	for {
+		b := board.NewBoard()
+		field := board.PlayerOne
+		decided := false
+		for i := 0; i < n && !decided; i++ {
+			moves := b.ValidMoves()
+			var outcome board.Outcome
+			b, outcome, _ = b.Play(moves[rng.Intn(len(moves))], field)
+			decided = outcome != board.Undecided
+			field = field.Opponent()
+		}
+		if !decided {
+			return b, field
+		}
+	}
+}
+
+// TestAnalyzeMatchesSearch compares the solver with an exhaustive search on
+// positions close to the end of the game, where a full-depth search is fast.
+func TestAnalyzeMatchesSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	s := New(1 << 16)
+	for i := 0; i < 20; i++ {
+		b, field := randomPosition(rng, 28+rng.Intn(6))
+		depth := board.Rows*board.Cols - b.Plies()
+		values, err := s.Analyze(b, field)
+		if err != nil {
+			t.Fatalf("analyze board \n%v\n: %v", b, err)
+		}
+		for _, mv := range values {
+			next, outcome, _ := b.Play(mv.Move, field)
+			var expected Result
+			var distance int
+			switch {
+			case outcome == board.Outcome(field):
+				expected, distance = Win, 1
+			case outcome == board.Tie:
+				expected, distance = Draw, 1
+			default:
+				score := -search.NewEngine(depth, nil, 1<<12).Search(next, field.Opponent()).Score
+				switch {
+				case score > 0:
+					expected, distance = Win, search.WinScore-score+1
+				case score < 0:
+					expected, distance = Loss, search.WinScore+score+1
+				default:
+					expected, distance = Draw, depth
+				}
+			}
+			if mv.Value.Result != expected || mv.Value.Distance != distance {
+				t.Errorf("expected move %d on board \n%v\n to be %v in %d, got %v",
+					mv.Move, b, expected, distance, mv.Value)
+			}
+		}
+	}
+}
+
+var solveTests = []struct {
+	notation string
+	result   Result
+	distance int
+}{
+	// player one wins with the next move
+	{"121212", Win, 1},
+	// player one creates two threats at once by playing 3 or 6
+	{"4455", Win, 3},
+	// player two can only block one of the threats
+	{"44553", Loss, 2},
+}
+
+func TestSolve(t *testing.T) {
+	s := New(1 << 16)
+	for _, test := range solveTests {
+		b, field, err := notation.Parse(test.notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Solve(b, field)
+		if err != nil {
+			t.Fatalf("solve %q: %v", test.notation, err)
+		}
+		if got.Result != test.result || got.Distance != test.distance {
+			t.Errorf("expected %q to be %v in %d, got %v", test.notation, test.result,
+				test.distance, got)
+		}
+	}
+}
+
+func TestSolveGameOver(t *testing.T) {
+	b, field, err := notation.Parse("1212121")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(1).Solve(b, field); err != ErrorGameOver {
+		t.Errorf("expected error %v, got %v", ErrorGameOver, err)
+	}
+}
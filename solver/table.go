@@ -0,0 +1,26 @@
+package solver
+
+// table is a transposition table storing upper bounds of position scores by
+// their key. Entries are replaced when their slot is taken by another key.
+type table struct {
+	keys   []uint64
+	values []int8
+}
+
+func newTable(size int) table {
+	return table{make([]uint64, size), make([]int8, size)}
+}
+
+func (t table) get(key uint64) (int, bool) {
+	i := key % uint64(len(t.keys))
+	if t.keys[i] != key {
+		return 0, false
+	}
+	return int(t.values[i]), true
+}
+
+func (t table) put(key uint64, upper int) {
+	i := key % uint64(len(t.keys))
+	t.keys[i] = key
+	t.values[i] = int8(upper)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"4iar/player"
+	"4iar/tournament"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+func main() {
+	names := strings.Join(player.Names(), ", ")
+	candidate := flag.String("candidate", "alphabeta", "candidate player ("+names+")")
+	baseline := flag.String("baseline", "minimax", "baseline player ("+names+")")
+	elo0 := flag.Float64("elo0", 0, "Elo gain of the candidate under H0")
+	elo1 := flag.Float64("elo1", 50, "Elo gain of the candidate under H1")
+	alpha := flag.Float64("alpha", 0.05, "probability of accepting H1 if H0 is true")
+	beta := flag.Float64("beta", 0.05, "probability of accepting H0 if H1 is true")
+	maxPairs := flag.Int("max", 10000, "maximum number of game pairs (0 for unlimited)")
+	moveTime := flag.Duration("movetime", 0, "time per move (0 for unlimited)")
+	gameTime := flag.Duration("gametime", 0, "time per player and game (0 for unlimited)")
+	seed := flag.Int64("seed", 0, "master seed for all players (0 for a random one)")
+	flag.Parse()
+	spawnCandidate, ok := player.Registry[*candidate]
+	if !ok {
+		log.Fatalf("unknown player '%s', choose one of: %s", *candidate, names)
+	}
+	spawnBaseline, ok := player.Registry[*baseline]
+	if !ok {
+		log.Fatalf("unknown player '%s', choose one of: %s", *baseline, names)
+	}
+	s := tournament.NewSPRT(*elo0, *elo1, *alpha, *beta)
+	s.MaxPairs = *maxPairs
+	s.MoveTime = *moveTime
+	s.GameTime = *gameTime
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	s.Seed = *seed
+	fmt.Printf("Seed: %d\n", s.Seed)
+	status, err := s.Run(context.Background(), spawnCandidate, spawnBaseline,
+		func(status tournament.SPRTStatus) {
+			fmt.Println(status)
+		})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("H1 (%s gains %.0f Elo over %s rather than %.0f): %v\n", *candidate, *elo1,
+		*baseline, *elo0, status.Verdict)
+}
@@ -0,0 +1,31 @@
+package tournament
+
+import "math"
+
+const (
+	// DefaultKFactor is the K-factor used if none is configured.
+	DefaultKFactor = 32
+	// DefaultInitialRating is the initial rating used if none is configured.
+	DefaultInitialRating = 1500
+)
+
+// ExpectedScore returns the score (1 for a win, 0.5 for a tie, 0 for a loss) a
+// player with the given Elo rating is expected to reach on average against an
+// opponent with the given rating.
+func ExpectedScore(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// UpdateRatings returns the new Elo ratings of two players after a game, in
+// which player one reached scoreOne (and player two 1-scoreOne). A single game
+// changes the ratings by no more than kFactor.
+func UpdateRatings(ratingOne, ratingTwo, scoreOne, kFactor float64) (float64, float64) {
+	delta := kFactor * (scoreOne - ExpectedScore(ratingOne, ratingTwo))
+	return ratingOne + delta, ratingTwo - delta
+}
+
+// EloDifference returns the Elo rating difference that corresponds to the
+// given expected score, which must be in the open interval (0;1).
+func EloDifference(score float64) float64 {
+	return -400 * math.Log10(1/score-1)
+}
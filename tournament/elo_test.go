@@ -0,0 +1,41 @@
+package tournament
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+var eloTests = []struct {
+	ratingOne float64
+	ratingTwo float64
+	scoreOne  float64
+	newOne    float64
+	newTwo    float64
+}{
+	{1500, 1500, 1, 1516, 1484},
+	{1500, 1500, 0.5, 1500, 1500},
+	{1500, 1500, 0, 1484, 1516},
+	{1900, 1500, 1, 1900 + 32*(1-1/1.1), 1500 - 32*(1-1/1.1)},
+	{1500, 1900, 0.5, 1500 + 32*(0.5-0.1/1.1), 1900 - 32*(0.5-0.1/1.1)},
+}
+
+func TestUpdateRatings(t *testing.T) {
+	for _, test := range eloTests {
+		one, two := UpdateRatings(test.ratingOne, test.ratingTwo, test.scoreOne, DefaultKFactor)
+		if math.Abs(one-test.newOne) > epsilon || math.Abs(two-test.newTwo) > epsilon {
+			t.Errorf("expected ratings %.2f/%.2f after %.1f for %.0f/%.0f, got %.2f/%.2f",
+				test.newOne, test.newTwo, test.scoreOne, test.ratingOne, test.ratingTwo, one, two)
+		}
+	}
+}
+
+func TestEloDifference(t *testing.T) {
+	for _, diff := range []float64{-400, -100, 0, 35, 200} {
+		score := ExpectedScore(1500+diff, 1500)
+		if got := EloDifference(score); math.Abs(got-diff) > 1e-6 {
+			t.Errorf("expected difference %.1f for score %.4f, got %.4f", diff, score, got)
+		}
+	}
+}
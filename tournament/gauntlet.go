@@ -0,0 +1,102 @@
+package tournament
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// GauntletOpponent is the outcome of the challenger of a gauntlet against one
+// of its opponents, from the challenger's point of view.
+type GauntletOpponent struct {
+	PlayerName string
+	Played     int
+	Won        int
+	Lost       int
+	Tied       int
+	// Score is the share of points the challenger reached, with a tie
+	// counting as half a win.
+	Score float64
+	// EloDifference is the challenger's Elo rating difference to the
+	// opponent implied by the score; infinite, if the challenger won or lost
+	// all games.
+	EloDifference float64
+}
+
+// Gauntlet is the outcome of a gauntlet.
+type Gauntlet struct {
+	Challenger string
+	// Opponents are the outcomes against all opponents in alphabetical
+	// order.
+	Opponents []GauntletOpponent
+	// Result contains the statistics of all players.
+	Result *Result
+}
+
+func (g *Gauntlet) String() string {
+	const headFormat = "%-16s\t%8s\t%8s\t%8s\t%8s\t%8s\t%8s\n"
+	const rowFormat = "%-16s\t%8d\t%8d\t%8d\t%8d\t%7.1f%%\t%+8.0f\n"
+	var sep16 = strings.Repeat("-", 16)
+	var sep8 = strings.Repeat("-", 8)
+	buf := bytes.NewBufferString("")
+	fmt.Fprintf(buf, "Challenger: %s\n\n", g.Challenger)
+	tw := new(tabwriter.Writer).Init(buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, headFormat, "Opponent", "Games", "Won", "Lost", "Tied", "Score", "Elo +/-")
+	fmt.Fprintf(tw, headFormat, sep16, sep8, sep8, sep8, sep8, sep8, sep8)
+	for _, o := range g.Opponents {
+		fmt.Fprintf(tw, rowFormat, o.PlayerName, o.Played, o.Won, o.Lost, o.Tied, 100*o.Score,
+			o.EloDifference)
+	}
+	tw.Flush()
+	return buf.String()
+}
+
+// PlayGauntlet lets the challenger play the given number of rounds against
+// every other player of the tournament, which don't play among themselves.
+// Like in Play, every round consists of a match and a rematch in flipped
+// order. If the challenger hasn't been added to the tournament, or it has no
+// opponents, an error is returned; an aborted tournament and a done ctx are
+// handled like in PlayContext.
+func (t *Tournament) PlayGauntlet(ctx context.Context, challenger string,
+	rounds int) (*Gauntlet, error) {
+	spawnChallenger, ok := t.players[challenger]
+	if !ok {
+		return nil, fmt.Errorf("unknown challenger '%s'", challenger)
+	}
+	if len(t.players) < 2 {
+		return nil, fmt.Errorf("unable to play a gauntlet without opponents for '%s'", challenger)
+	}
+	opponents := make([]string, 0, len(t.players)-1)
+	for name := range t.players {
+		if name != challenger {
+			opponents = append(opponents, name)
+		}
+	}
+	sort.Strings(opponents)
+	pairings := make([]Pairing, 0, 2*rounds*len(opponents))
+	for r := 0; r < rounds; r++ {
+		for _, opponent := range opponents {
+			one := Player{challenger, spawnChallenger}
+			two := Player{opponent, t.players[opponent]}
+			pairings = append(pairings, Pairing{one, two}, Pairing{two, one})
+		}
+	}
+	sb := t.newScoreboard()
+	sb.total = len(pairings)
+	_, err := t.playGames(ctx, pairings, sb)
+	gauntlet := &Gauntlet{Challenger: challenger, Result: sb.result()}
+	for _, opponent := range opponents {
+		stats := sb.versus(challenger, opponent)
+		o := GauntletOpponent{PlayerName: opponent, Played: stats.Played, Won: stats.Won,
+			Lost: stats.Lost, Tied: stats.Tied}
+		if o.Played > 0 {
+			o.Score = (float64(o.Won) + float64(o.Tied)/2) / float64(o.Played)
+			o.EloDifference = EloDifference(o.Score)
+		}
+		gauntlet.Opponents = append(gauntlet.Opponents, o)
+	}
+	return gauntlet, err
+}
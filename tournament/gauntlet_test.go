@@ -0,0 +1,53 @@
+package tournament
+
+import (
+	"4iar/player"
+	"context"
+	"testing"
+)
+
+func TestPlayGauntlet(t *testing.T) {
+	const rounds = 3
+	tm := NewTournament()
+	tm.AddPlayer("Left", newLeftmostPlayer)
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Winning", player.NewWinningMovePlayer)
+	tm.AddPlayer("Challenger", newLeftmostPlayer)
+	gauntlet, err := tm.PlayGauntlet(context.Background(), "Challenger", rounds)
+	if err != nil {
+		t.Fatalf("play gauntlet: %v", err)
+	}
+	if len(gauntlet.Opponents) != len(tm.players)-1 {
+		t.Fatalf("expected %d opponents, got %d", len(tm.players)-1, len(gauntlet.Opponents))
+	}
+	for _, o := range gauntlet.Opponents {
+		if o.Played != 2*rounds || o.Won+o.Lost+o.Tied != o.Played {
+			t.Errorf("expected %d games against %s, got %d won, %d lost, %d tied out of %d",
+				2*rounds, o.PlayerName, o.Won, o.Lost, o.Tied, o.Played)
+		}
+	}
+	// the first mover wins every game between two leftmost players
+	if left := gauntlet.Opponents[0]; left.Score != 0.5 || left.EloDifference != 0 {
+		t.Errorf("expected score 0.5 and no Elo difference against Left, got %.2f and %.0f",
+			left.Score, left.EloDifference)
+	}
+	for _, stats := range gauntlet.Result.Standings {
+		expected := 2 * rounds
+		if stats.PlayerName == "Challenger" {
+			expected *= len(tm.players) - 1
+		}
+		if stats.Played != expected {
+			t.Errorf("expected %s to play %d games, played %d", stats.PlayerName, expected,
+				stats.Played)
+		}
+	}
+}
+
+func TestPlayGauntletUnknownChallenger(t *testing.T) {
+	tm := NewTournament()
+	tm.AddPlayer("Left", newLeftmostPlayer)
+	tm.AddPlayer("Right", newLeftmostPlayer)
+	if _, err := tm.PlayGauntlet(context.Background(), "Center", 1); err == nil {
+		t.Error("expected error for unknown challenger, got none")
+	}
+}
@@ -0,0 +1,404 @@
+package tournament
+
+import (
+	"4iar/board"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+)
+
+// Elimination determines how many series a player has to lose to drop out of
+// a knockout tournament.
+type Elimination int
+
+const (
+	// SingleElimination drops out players after their first lost series.
+	SingleElimination Elimination = iota
+	// DoubleElimination drops out players of the winners bracket into the
+	// losers bracket, and out of the tournament after their second lost
+	// series.
+	DoubleElimination
+)
+
+// TieBreak determines the player advancing from a drawn series.
+type TieBreak int
+
+const (
+	// SuddenDeath plays additional games with alternating first mover until
+	// one of them is won. If as many games as the series has are tied, the
+	// higher-seeded player advances.
+	SuddenDeath TieBreak = iota
+	// HigherSeed advances the higher-seeded player.
+	HigherSeed
+)
+
+var tieBreakNames = map[TieBreak]string{
+	SuddenDeath: "suddendeath",
+	HigherSeed:  "seed",
+}
+
+func (t TieBreak) String() string {
+	if name, ok := tieBreakNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("tiebreak(%d)", int(t))
+}
+
+// ParseTieBreak returns the tie-break rule with the given name, i.e.
+// "suddendeath" or "seed".
+func ParseTieBreak(name string) (TieBreak, error) {
+	for tieBreak, tieBreakName := range tieBreakNames {
+		if tieBreakName == name {
+			return tieBreak, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tie-break rule '%s'", name)
+}
+
+// Knockout is the configuration of a knockout tournament.
+type Knockout struct {
+	Elimination Elimination
+	// BestOf is the number of games of a series (1, if zero), which is won
+	// by the player winning more of them.
+	BestOf int
+	// TieBreak decides drawn series.
+	TieBreak TieBreak
+	// Seeds are the names of the players from the highest to the lowest
+	// seed. Players not listed are seeded after them in alphabetical order.
+	Seeds []string
+}
+
+// Series is a mini-match of up to BestOf games between two players of a
+// knockout tournament, with the first mover alternating between the games. A
+// player without opponent advances by a bye, which is a series without games.
+type Series struct {
+	// PlayerOneName is the name of the higher-seeded player, who moves first
+	// in the first game.
+	PlayerOneName string `json:"playerOne"`
+	// PlayerTwoName is the name of the lower-seeded player, or empty for a
+	// bye.
+	PlayerTwoName string `json:"playerTwo"`
+	SeedOne       int    `json:"seedOne"`
+	SeedTwo       int    `json:"seedTwo"`
+	WinsOne       int    `json:"winsOne"`
+	WinsTwo       int    `json:"winsTwo"`
+	Ties          int    `json:"ties"`
+	// Winner is the name of the player advancing from the series.
+	Winner string `json:"winner"`
+	// TieBroken is true if the series was drawn and decided by the
+	// tie-break rule.
+	TieBroken bool    `json:"tieBroken"`
+	Matches   []Match `json:"-"`
+}
+
+// Loser returns the name of the player dropping out of the series, or an empty
+// string for a bye.
+func (s *Series) Loser() string {
+	if s.Winner == s.PlayerOneName {
+		return s.PlayerTwoName
+	}
+	return s.PlayerOneName
+}
+
+// Bracket is the outcome of a knockout tournament. It can be exported using
+// encoding/json.
+type Bracket struct {
+	// Winners are the rounds of the winners bracket.
+	Winners [][]*Series `json:"winners"`
+	// Losers are the rounds of the losers bracket of a double elimination.
+	Losers [][]*Series `json:"losers,omitempty"`
+	// Final is the grand final of a double elimination, followed by a
+	// second one, if the winner of the losers bracket won the first one.
+	Final    []*Series `json:"final,omitempty"`
+	Champion string    `json:"champion"`
+	// Result contains the statistics of all games played.
+	Result *Result `json:"-"`
+}
+
+func (b *Bracket) String() string {
+	buf := bytes.NewBufferString("")
+	tw := new(tabwriter.Writer).Init(buf, 0, 8, 2, ' ', 0)
+	writeRounds(tw, "Winners bracket", b.Winners)
+	writeRounds(tw, "Losers bracket", b.Losers)
+	if len(b.Final) > 0 {
+		writeRounds(tw, "Grand final", [][]*Series{b.Final})
+	}
+	fmt.Fprintf(tw, "Champion: %s\n", b.Champion)
+	tw.Flush()
+	return buf.String()
+}
+
+func writeRounds(tw *tabwriter.Writer, title string, rounds [][]*Series) {
+	if len(rounds) == 0 {
+		return
+	}
+	fmt.Fprintf(tw, "%s\n", title)
+	for r, round := range rounds {
+		if len(rounds) > 1 {
+			fmt.Fprintf(tw, "Round %d\n", r+1)
+		}
+		for _, s := range round {
+			switch {
+			case s.PlayerOneName == "" && s.PlayerTwoName == "":
+				continue
+			case s.PlayerTwoName == "":
+				fmt.Fprintf(tw, "  (%d) %s\t\tbye\t\n", s.SeedOne, s.PlayerOneName)
+				continue
+			}
+			note := ""
+			if s.TieBroken {
+				note = "tie-break"
+			}
+			fmt.Fprintf(tw, "  (%d) %s\t%d-%d\t(%d) %s\t%s\n", s.SeedOne, s.PlayerOneName,
+				s.WinsOne, s.WinsTwo, s.SeedTwo, s.PlayerTwoName, note)
+		}
+	}
+	fmt.Fprintln(tw)
+}
+
+// knockout holds the state of a knockout tournament.
+type knockout struct {
+	Knockout
+	ctx   context.Context
+	t     *Tournament
+	sb    *scoreboard
+	seeds map[string]int
+}
+
+// PlayKnockout plays a knockout tournament, where every pairing is decided by
+// a series of games, and returns the resulting bracket. In every round, the
+// first half of the players still in the bracket is paired up with the second
+// half in reversed order, i.e. the highest with the lowest seed, so that the
+// highest seeds meet last. If the number of players is no power of two, the
+// highest seeds get a bye in the first round. If less than two players have
+// been added to the tournament, or a seeded player is unknown, an error is
+// returned; an aborted tournament and a done ctx are handled like in
+// PlayContext, returning the bracket so far.
+func (t *Tournament) PlayKnockout(ctx context.Context, config Knockout) (*Bracket, error) {
+	if len(t.players) < 2 {
+		return nil, errors.New("unable to play a tournament with less than two players")
+	}
+	if config.BestOf < 1 {
+		config.BestOf = 1
+	}
+	seeds, err := seeding(t.players, config.Seeds)
+	if err != nil {
+		return nil, err
+	}
+	k := knockout{config, ctx, t, t.newScoreboard(), make(map[string]int, len(seeds))}
+	for i, name := range seeds {
+		k.seeds[name] = i + 1
+	}
+	size := 1
+	for size < len(seeds) {
+		size *= 2
+	}
+	entrants := make([]string, size)
+	copy(entrants, seeds)
+	bracket := &Bracket{}
+	defer func() {
+		bracket.Result = k.sb.result()
+	}()
+	var dropped [][]string
+	for len(entrants) > 1 {
+		half := len(entrants) / 2
+		round := k.pairUp(entrants[:half], reversed(entrants[half:]))
+		bracket.Winners = append(bracket.Winners, round)
+		if err := k.play(round); err != nil {
+			return bracket, err
+		}
+		entrants = winners(round)
+		dropped = append(dropped, losers(round))
+	}
+	bracket.Champion = entrants[0]
+	if config.Elimination != DoubleElimination {
+		return bracket, nil
+	}
+	survivors := dropped[0]
+	for i, drops := range dropped {
+		if i > 0 {
+			round := k.pairUp(survivors, reversed(drops))
+			bracket.Losers = append(bracket.Losers, round)
+			if err := k.play(round); err != nil {
+				return bracket, err
+			}
+			survivors = winners(round)
+		}
+		if len(survivors) > 1 {
+			half := len(survivors) / 2
+			round := k.pairUp(survivors[:half], reversed(survivors[half:]))
+			bracket.Losers = append(bracket.Losers, round)
+			if err := k.play(round); err != nil {
+				return bracket, err
+			}
+			survivors = winners(round)
+		}
+	}
+	final := k.newSeries(bracket.Champion, survivors[0])
+	bracket.Final = []*Series{final}
+	if err := k.play(bracket.Final); err != nil {
+		return bracket, err
+	}
+	bracket.Champion = final.Winner
+	if final.Winner == survivors[0] {
+		reset := k.newSeries(final.PlayerOneName, final.PlayerTwoName)
+		bracket.Final = append(bracket.Final, reset)
+		if err := k.play([]*Series{reset}); err != nil {
+			return bracket, err
+		}
+		bracket.Champion = reset.Winner
+	}
+	return bracket, nil
+}
+
+// seeding returns the names of all players, starting with the given seeds,
+// followed by all other players in alphabetical order.
+func seeding(players map[string]PlayerSpawnFunc, seeds []string) ([]string, error) {
+	names := make([]string, 0, len(players))
+	seeded := make(map[string]bool, len(seeds))
+	for _, name := range seeds {
+		if _, ok := players[name]; !ok {
+			return nil, fmt.Errorf("unknown seeded player '%s'", name)
+		}
+		if seeded[name] {
+			return nil, fmt.Errorf("player '%s' was seeded twice", name)
+		}
+		seeded[name] = true
+		names = append(names, name)
+	}
+	others := make([]string, 0, len(players)-len(names))
+	for name := range players {
+		if !seeded[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(names, others...), nil
+}
+
+func reversed(names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[len(names)-1-i] = name
+	}
+	return result
+}
+
+func winners(round []*Series) []string {
+	names := make([]string, len(round))
+	for i, s := range round {
+		names[i] = s.Winner
+	}
+	return names
+}
+
+func losers(round []*Series) []string {
+	names := make([]string, len(round))
+	for i, s := range round {
+		names[i] = s.Loser()
+	}
+	return names
+}
+
+// pairUp creates a series for the players at the same index of both slices.
+func (k *knockout) pairUp(left, right []string) []*Series {
+	round := make([]*Series, len(left))
+	for i := range left {
+		round[i] = k.newSeries(left[i], right[i])
+	}
+	return round
+}
+
+// newSeries creates a series between the given players, with the
+// higher-seeded player or the one of the given players that isn't a bye as
+// player one.
+func (k *knockout) newSeries(one, two string) *Series {
+	if one == "" || (two != "" && k.seeds[two] < k.seeds[one]) {
+		one, two = two, one
+	}
+	return &Series{PlayerOneName: one, PlayerTwoName: two, SeedOne: k.seeds[one],
+		SeedTwo: k.seeds[two]}
+}
+
+// play plays the series of a round until all of them are decided, with the
+// next games of all series being played concurrently.
+func (k *knockout) play(round []*Series) error {
+	for {
+		pairings := make([]Pairing, 0, len(round))
+		series := make(map[string]*Series, len(round))
+		for _, s := range round {
+			one, two, ok := k.next(s)
+			if !ok {
+				continue
+			}
+			pairings = append(pairings, Pairing{
+				Player{one, k.t.players[one]},
+				Player{two, k.t.players[two]},
+			})
+			series[one] = s
+		}
+		if len(pairings) == 0 {
+			return nil
+		}
+		matches, err := k.t.playGames(k.ctx, pairings, k.sb)
+		for _, match := range matches {
+			series[match.PlayerOneName].add(match, k.t.ErrorPolicy)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// next returns the players of the next game of the series, with the player
+// moving first in front, or decides the series and returns false.
+func (k *knockout) next(s *Series) (string, string, bool) {
+	played := len(s.Matches)
+	decide := func(winner string) (string, string, bool) {
+		s.Winner = winner
+		s.TieBroken = played > k.BestOf || (played > 0 && s.WinsOne == s.WinsTwo)
+		return "", "", false
+	}
+	switch {
+	case s.PlayerTwoName == "":
+		return decide(s.PlayerOneName)
+	case 2*s.WinsOne > k.BestOf:
+		return decide(s.PlayerOneName)
+	case 2*s.WinsTwo > k.BestOf:
+		return decide(s.PlayerTwoName)
+	case played >= k.BestOf && s.WinsOne > s.WinsTwo:
+		return decide(s.PlayerOneName)
+	case played >= k.BestOf && s.WinsTwo > s.WinsOne:
+		return decide(s.PlayerTwoName)
+	case played >= k.BestOf && (k.TieBreak == HigherSeed || played >= 2*k.BestOf):
+		return decide(s.PlayerOneName)
+	case played%2 == 0:
+		return s.PlayerOneName, s.PlayerTwoName, true
+	default:
+		return s.PlayerTwoName, s.PlayerOneName, true
+	}
+}
+
+// add scores a game of the series. Failed and undecided games count as tied,
+// as do forfeits voided by the policy, which would otherwise decide the series
+// although they don't count in the standings.
+func (s *Series) add(match Match, policy ErrorPolicy) {
+	s.Matches = append(s.Matches, match)
+	outcome := board.Undecided
+	if match.Err == nil && match.Record != nil &&
+		!(policy == Void && match.Record.Forfeiter != board.Empty) {
+		outcome = match.Record.Outcome
+	}
+	switch {
+	case outcome == board.PlayerOneWins && match.PlayerOneName == s.PlayerOneName,
+		outcome == board.PlayerTwoWins && match.PlayerTwoName == s.PlayerOneName:
+		s.WinsOne++
+	case outcome == board.PlayerOneWins, outcome == board.PlayerTwoWins:
+		s.WinsTwo++
+	default:
+		s.Ties++
+	}
+}
@@ -0,0 +1,148 @@
+package tournament
+
+import (
+	"4iar/board"
+	"4iar/player"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// leftmostPlayer plays the leftmost valid move, so that the player moving
+// first wins.
+type leftmostPlayer struct {
+	field board.Field
+}
+
+func newLeftmostPlayer(field board.Field, seed int64) *player.Player {
+	p := player.Player(&leftmostPlayer{field})
+	return &p
+}
+
+func (p *leftmostPlayer) Play(b *board.Board) *board.Move {
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *leftmostPlayer) Field() board.Field {
+	return p.field
+}
+
+func TestPlayKnockout(t *testing.T) {
+	for _, elimination := range []Elimination{SingleElimination, DoubleElimination} {
+		for _, players := range []int{2, 5, 8} {
+			tm := NewTournament()
+			for i := 0; i < players; i++ {
+				tm.AddPlayer(fmt.Sprintf("Random %d", i), player.NewRandomPlayer)
+			}
+			bracket, err := tm.PlayKnockout(context.Background(),
+				Knockout{Elimination: elimination, BestOf: 3})
+			if err != nil {
+				t.Fatalf("play tournament: %v", err)
+			}
+			if bracket.Champion == "" {
+				t.Fatalf("expected a champion, got none")
+			}
+			rounds := append(append(bracket.Winners, bracket.Losers...), bracket.Final)
+			lost := make(map[string]int)
+			for _, round := range rounds {
+				for _, s := range round {
+					if loser := s.Loser(); loser != "" {
+						lost[loser]++
+					}
+					if s.PlayerTwoName != "" && len(s.Matches) > 3 {
+						t.Errorf("expected at most 3 games in series, got %d", len(s.Matches))
+					}
+				}
+			}
+			// every player but the champion drops out after losing the
+			// number of series given by the elimination
+			for name := range tm.players {
+				expected := int(elimination) + 1
+				if name == bracket.Champion {
+					if lost[name] >= expected {
+						t.Errorf("expected champion %s to lose less than %d series, lost %d",
+							name, expected, lost[name])
+					}
+				} else if lost[name] != expected {
+					t.Errorf("expected %s to lose %d series, lost %d", name, expected, lost[name])
+				}
+			}
+			if _, err := json.Marshal(bracket); err != nil {
+				t.Errorf("export bracket: %v", err)
+			}
+		}
+	}
+}
+
+var tieBreakTests = []struct {
+	tieBreak TieBreak
+	games    int
+}{
+	{HigherSeed, 2},
+	{SuddenDeath, 3},
+}
+
+func TestPlayKnockoutTieBreak(t *testing.T) {
+	for _, test := range tieBreakTests {
+		tm := NewTournament()
+		tm.AddPlayer("Left", newLeftmostPlayer)
+		tm.AddPlayer("Right", newLeftmostPlayer)
+		// the first mover wins every game, so that the series is drawn
+		bracket, err := tm.PlayKnockout(context.Background(),
+			Knockout{BestOf: 2, TieBreak: test.tieBreak, Seeds: []string{"Right"}})
+		if err != nil {
+			t.Fatalf("play tournament: %v", err)
+		}
+		s := bracket.Winners[0][0]
+		if s.Winner != "Right" || !s.TieBroken {
+			t.Errorf("expected higher seed Right to win by %v, got %s (tie-break: %v)",
+				test.tieBreak, s.Winner, s.TieBroken)
+		}
+		if len(s.Matches) != test.games {
+			t.Errorf("expected %d games, got %d", test.games, len(s.Matches))
+		}
+		for i, match := range s.Matches {
+			if first := []string{"Right", "Left"}[i%2]; match.PlayerOneName != first {
+				t.Errorf("expected %s to move first in game %d, got %s", first, i+1,
+					match.PlayerOneName)
+			}
+		}
+	}
+}
+
+func TestPlayKnockoutForfeits(t *testing.T) {
+	for _, test := range []struct {
+		policy ErrorPolicy
+		winner string
+	}{
+		{CountAsLoss, "Greedy"},
+		// the voided games are tied, so that the higher seed wins
+		{Void, "Panicking"},
+	} {
+		tm := NewTournament()
+		tm.ErrorPolicy = test.policy
+		tm.AddPlayer("Greedy", newGreedyPlayer)
+		tm.AddPlayer("Panicking", newPanickingPlayer)
+		bracket, err := tm.PlayKnockout(context.Background(),
+			Knockout{BestOf: 3, TieBreak: HigherSeed, Seeds: []string{"Panicking"}})
+		if err != nil {
+			t.Fatalf("play tournament: %v", err)
+		}
+		if s := bracket.Winners[0][0]; s.Winner != test.winner {
+			t.Errorf("expected %s to win with policy %v, got %s (%d-%d-%d)", test.winner,
+				test.policy, s.Winner, s.WinsOne, s.WinsTwo, s.Ties)
+		}
+	}
+}
+
+func TestPlayKnockoutUnknownSeed(t *testing.T) {
+	tm := NewTournament()
+	tm.AddPlayer("Left", newLeftmostPlayer)
+	tm.AddPlayer("Right", newLeftmostPlayer)
+	_, err := tm.PlayKnockout(context.Background(), Knockout{Seeds: []string{"Center"}})
+	if err == nil {
+		t.Error("expected error seeding unknown player, got none")
+	}
+}
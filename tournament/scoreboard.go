@@ -0,0 +1,176 @@
+package tournament
+
+import (
+	"4iar/board"
+	"4iar/game"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// scoreboard accumulates the statistics and ratings of players from the
+// matches they played.
+type scoreboard struct {
+	stats       map[string]*PlayerStatistics
+	kFactor     float64
+	keepRecords bool
+	policy      ErrorPolicy
+	matches     []Match
+	// headToHead contains the statistics of the first player of the key in
+	// the games against the second player.
+	headToHead map[[2]string]*PlayerStatistics
+	progress   func(Progress)
+	total      int
+	done       int
+	start      time.Time
+	// scheduled is the number of games scheduled so far.
+	scheduled int
+}
+
+func (t *Tournament) newScoreboard() *scoreboard {
+	kFactor := t.KFactor
+	if kFactor == 0 {
+		kFactor = DefaultKFactor
+	}
+	initialRating := t.InitialRating
+	if initialRating == 0 {
+		initialRating = DefaultInitialRating
+	}
+	stats := make(map[string]*PlayerStatistics, len(t.players))
+	for name := range t.players {
+		stats[name] = &PlayerStatistics{PlayerName: name, Rating: initialRating}
+	}
+	return &scoreboard{
+		stats:       stats,
+		kFactor:     kFactor,
+		keepRecords: t.KeepRecords,
+		policy:      t.ErrorPolicy,
+		matches:     make([]Match, 0),
+		headToHead:  make(map[[2]string]*PlayerStatistics),
+		progress:    t.Progress,
+		start:       time.Now(),
+	}
+}
+
+// add scores the outcome of the match for both its players. Forfeited and
+// failed matches are scored according to the error policy; an error is
+// returned if they abort the tournament.
+func (s *scoreboard) add(match Match) error {
+	one, okOne := s.stats[match.PlayerOneName]
+	two, okTwo := s.stats[match.PlayerTwoName]
+	if !okOne || !okTwo {
+		log.Printf("no stats found for %s or %s", match.PlayerOneName, match.PlayerTwoName)
+		return nil
+	}
+	if match.Err != nil {
+		if s.policy == Abort {
+			return fmt.Errorf("%w: match between %s and %s failed: %v", ErrorAborted,
+				match.PlayerOneName, match.PlayerTwoName, match.Err)
+		}
+		log.Printf("match between %s and %s failed: %v", match.PlayerOneName,
+			match.PlayerTwoName, match.Err)
+		return nil
+	}
+	if forfeiter := match.Record.Forfeiter; forfeiter != board.Empty {
+		name, stats := match.PlayerOneName, one
+		if forfeiter == board.PlayerTwo {
+			name, stats = match.PlayerTwoName, two
+		}
+		stats.Errors++
+		switch s.policy {
+		case Abort:
+			return fmt.Errorf("%w: %s forfeited by %v: %v", ErrorAborted, name,
+				match.Record.Termination, match.Record.Err)
+		case Void:
+			return nil
+		}
+	}
+	deltaStatOne := PlayerStatistics{Played: 1}
+	deltaStatTwo := PlayerStatistics{Played: 1}
+	var scoreOne float64
+	switch match.Record.Outcome {
+	case board.PlayerOneWins:
+		deltaStatOne.Won = 1
+		deltaStatOne.Points = WinPoints
+		deltaStatTwo.Lost = 1
+		scoreOne = 1
+	case board.PlayerTwoWins:
+		deltaStatOne.Lost = 1
+		deltaStatTwo.Won = 1
+		deltaStatTwo.Points = WinPoints
+	case board.Tie:
+		deltaStatOne.Tied = 1
+		deltaStatOne.Points = TiePoints
+		deltaStatTwo.Tied = 1
+		deltaStatTwo.Points = TiePoints
+		scoreOne = 0.5
+	default:
+		if match.Record.Termination != game.Cancelled {
+			log.Printf("match between %s and %s is undecided", match.PlayerOneName,
+				match.PlayerTwoName)
+		}
+		return nil
+	}
+	one.Apply(&deltaStatOne)
+	two.Apply(&deltaStatTwo)
+	one.Rating, two.Rating = UpdateRatings(one.Rating, two.Rating, scoreOne, s.kFactor)
+	s.versus(one.PlayerName, two.PlayerName).Apply(&deltaStatOne)
+	s.versus(two.PlayerName, one.PlayerName).Apply(&deltaStatTwo)
+	if s.keepRecords {
+		s.matches = append(s.matches, match)
+	}
+	return nil
+}
+
+// versus returns the statistics of the player in the games against the
+// opponent.
+func (s *scoreboard) versus(name, opponent string) *PlayerStatistics {
+	key := [2]string{name, opponent}
+	stats, ok := s.headToHead[key]
+	if !ok {
+		stats = &PlayerStatistics{PlayerName: name}
+		s.headToHead[key] = stats
+	}
+	return stats
+}
+
+// bye awards the points of a win to the player without a game being played.
+func (s *scoreboard) bye(name string) {
+	if stats, ok := s.stats[name]; ok {
+		stats.Points += WinPoints
+	}
+}
+
+// report counts a finished game and reports the progress of the tournament,
+// if requested.
+func (s *scoreboard) report() {
+	s.done++
+	if s.progress == nil {
+		return
+	}
+	elapsed := time.Since(s.start)
+	var eta time.Duration
+	if s.total > s.done {
+		eta = elapsed / time.Duration(s.done) * time.Duration(s.total-s.done)
+	}
+	standings := s.result().Standings
+	sort.Sort(sort.Reverse(standings))
+	s.progress(Progress{s.done, s.total, elapsed, eta, standings})
+}
+
+// result returns the statistics of all players, with the Buchholz score being
+// the sum of the points of the opponents of every game scored.
+func (s *scoreboard) result() *Result {
+	for _, stat := range s.stats {
+		stat.Buchholz = 0
+	}
+	for players, versus := range s.headToHead {
+		s.stats[players[0]].Buchholz += versus.Played * s.stats[players[1]].Points
+	}
+	standings := make([]PlayerStatistics, 0, len(s.stats))
+	for _, stat := range s.stats {
+		standings = append(standings, *stat)
+	}
+	return &Result{Standings(standings), s.matches}
+}
@@ -0,0 +1,165 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Verdict is the outcome of a sequential probability ratio test.
+type Verdict int
+
+const (
+	// Inconclusive means that the test was stopped before the hypothesis
+	// could be accepted or rejected.
+	Inconclusive Verdict = iota
+	// Accepted means that the candidate is stronger by Elo1 rather than
+	// Elo0.
+	Accepted
+	// Rejected means that the candidate is stronger by Elo0 rather than
+	// Elo1.
+	Rejected
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Inconclusive:
+		return "inconclusive"
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	}
+	return fmt.Sprintf("verdict(%d)", int(v))
+}
+
+// SPRT is a sequential probability ratio test of the hypothesis that a
+// candidate player is stronger than a baseline player by Elo1 rating points
+// (H1), against the hypothesis that it is stronger by Elo0 (H0).
+type SPRT struct {
+	Elo0 float64
+	Elo1 float64
+	// Alpha is the probability of accepting H1 if H0 is true.
+	Alpha float64
+	// Beta is the probability of accepting H0 if H1 is true.
+	Beta float64
+	// MaxPairs is the number of game pairs after which the test is stopped
+	// as inconclusive; unlimited, if zero.
+	MaxPairs int
+	// MoveTime is the time a player has to pick a single move; unlimited, if
+	// zero.
+	MoveTime time.Duration
+	// GameTime is the time a player has to pick all moves of a game;
+	// unlimited, if zero.
+	GameTime time.Duration
+	// Seed is the master seed, from which the seeds of the players of every
+	// game are derived.
+	Seed int64
+}
+
+// NewSPRT creates a test of the hypotheses with the given error
+// probabilities.
+func NewSPRT(elo0, elo1, alpha, beta float64) *SPRT {
+	return &SPRT{Elo0: elo0, Elo1: elo1, Alpha: alpha, Beta: beta}
+}
+
+// SPRTStatus is the state of a test after a number of game pairs, with the
+// games counted from the candidate's point of view.
+type SPRTStatus struct {
+	Pairs int
+	Won   int
+	Lost  int
+	Tied  int
+	// LLR is the log-likelihood ratio of H1 against H0.
+	LLR float64
+	// Lower is the bound of the LLR to accept H0.
+	Lower float64
+	// Upper is the bound of the LLR to accept H1.
+	Upper   float64
+	Verdict Verdict
+}
+
+func (s SPRTStatus) String() string {
+	return fmt.Sprintf("pairs %d: +%d -%d =%d, LLR %.2f [%.2f, %.2f]", s.Pairs, s.Won,
+		s.Lost, s.Tied, s.LLR, s.Lower, s.Upper)
+}
+
+// Bounds returns the lower and upper bounds of the log-likelihood ratio, at
+// which H0 or H1 is accepted, respectively.
+func (s *SPRT) Bounds() (float64, float64) {
+	return math.Log(s.Beta / (1 - s.Alpha)), math.Log((1 - s.Beta) / s.Alpha)
+}
+
+// LLR returns the log-likelihood ratio of H1 against H0 for the given game
+// results, using a normal approximation of the score distribution. Half a game
+// is added to every result, so that the ratio is defined without variance in
+// the results, e.g. if one player won all games.
+func (s *SPRT) LLR(won, lost, tied int) float64 {
+	w, l, d := float64(won)+0.5, float64(lost)+0.5, float64(tied)+0.5
+	n := w + l + d
+	score := (w + d/2) / n
+	variance := (w*math.Pow(1-score, 2) + d*math.Pow(0.5-score, 2) + l*math.Pow(score, 2)) / n
+	s0 := ExpectedScore(s.Elo0, 0)
+	s1 := ExpectedScore(s.Elo1, 0)
+	return n * (s1 - s0) * (2*score - s0 - s1) / (2 * variance)
+}
+
+// Run plays pairs of games between the candidate and the baseline, each
+// moving first once, until the hypothesis is accepted or rejected, MaxPairs
+// is reached, or ctx is done. The status of the test is passed to progress
+// after every pair, if not nil. Games lost by forfeit count as losses, and
+// failed games are not counted.
+func (s *SPRT) Run(ctx context.Context, candidate, baseline PlayerSpawnFunc,
+	progress func(SPRTStatus)) (*SPRTStatus, error) {
+	if s.Alpha <= 0 || s.Alpha >= 1 || s.Beta <= 0 || s.Beta >= 1 {
+		return nil, errors.New("alpha and beta must be in the open interval (0;1)")
+	}
+	if s.Elo1 <= s.Elo0 {
+		return nil, errors.New("elo1 must be greater than elo0")
+	}
+	const candidateName, baselineName = "candidate", "baseline"
+	t := NewTournament()
+	t.MoveTime = s.MoveTime
+	t.GameTime = s.GameTime
+	t.Seed = s.Seed
+	if err := t.AddPlayer(candidateName, candidate); err != nil {
+		return nil, err
+	}
+	if err := t.AddPlayer(baselineName, baseline); err != nil {
+		return nil, err
+	}
+	sb := t.newScoreboard()
+	sb.total = 2 * s.MaxPairs
+	status := &SPRTStatus{}
+	status.Lower, status.Upper = s.Bounds()
+	for s.MaxPairs == 0 || status.Pairs < s.MaxPairs {
+		pairings := []Pairing{
+			{Player{candidateName, candidate}, Player{baselineName, baseline}},
+			{Player{baselineName, baseline}, Player{candidateName, candidate}},
+		}
+		if _, err := t.playGames(ctx, pairings, sb); err != nil {
+			return status, err
+		}
+		if ctx.Err() != nil {
+			return status, ctx.Err()
+		}
+		stats := sb.versus(candidateName, baselineName)
+		status.Pairs++
+		status.Won, status.Lost, status.Tied = stats.Won, stats.Lost, stats.Tied
+		status.LLR = s.LLR(status.Won, status.Lost, status.Tied)
+		if status.LLR >= status.Upper {
+			status.Verdict = Accepted
+		} else if status.LLR <= status.Lower {
+			status.Verdict = Rejected
+		}
+		if progress != nil {
+			progress(*status)
+		}
+		if status.Verdict != Inconclusive {
+			break
+		}
+	}
+	return status, nil
+}
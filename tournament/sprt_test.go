@@ -0,0 +1,70 @@
+package tournament
+
+import (
+	"4iar/player"
+	"context"
+	"testing"
+)
+
+func TestLLR(t *testing.T) {
+	s := NewSPRT(0, 50, 0.05, 0.05)
+	if llr := s.LLR(100, 0, 0); llr <= 0 {
+		t.Errorf("expected positive LLR for all games won, got %.2f", llr)
+	}
+	if llr := s.LLR(0, 100, 0); llr >= 0 {
+		t.Errorf("expected negative LLR for all games lost, got %.2f", llr)
+	}
+	if llr, more := s.LLR(60, 40, 0), s.LLR(120, 80, 0); more <= llr {
+		t.Errorf("expected LLR to grow with the number of games, got %.2f and %.2f", llr, more)
+	}
+	lower, upper := s.Bounds()
+	if lower >= 0 || upper <= 0 {
+		t.Errorf("expected bounds around zero, got [%.2f, %.2f]", lower, upper)
+	}
+}
+
+var sprtTests = []struct {
+	candidate PlayerSpawnFunc
+	baseline  PlayerSpawnFunc
+	elo0      float64
+	elo1      float64
+	verdict   Verdict
+}{
+	{newGreedyPlayer, player.NewRandomPlayer, 0, 100, Accepted},
+	// the first mover wins every game between two leftmost players
+	{newLeftmostPlayer, newLeftmostPlayer, 0, 100, Rejected},
+	{newLeftmostPlayer, newLeftmostPlayer, -100, 100, Inconclusive},
+}
+
+func TestSPRTRun(t *testing.T) {
+	for _, test := range sprtTests {
+		s := NewSPRT(test.elo0, test.elo1, 0.05, 0.05)
+		s.MaxPairs = 200
+		var pairs int
+		status, err := s.Run(context.Background(), test.candidate, test.baseline,
+			func(status SPRTStatus) {
+				pairs++
+				if status.Pairs != pairs {
+					t.Errorf("expected progress after pair %d, got %d", pairs, status.Pairs)
+				}
+			})
+		if err != nil {
+			t.Fatalf("run test: %v", err)
+		}
+		if status.Verdict != test.verdict {
+			t.Errorf("expected verdict %v, got %v", test.verdict, status)
+		}
+		if status.Won+status.Lost+status.Tied != 2*status.Pairs {
+			t.Errorf("expected %d games, got %v", 2*status.Pairs, status)
+		}
+	}
+}
+
+func TestSPRTRunInvalid(t *testing.T) {
+	for _, s := range []*SPRT{NewSPRT(0, 50, 0, 0.05), NewSPRT(50, 0, 0.05, 0.05)} {
+		if _, err := s.Run(context.Background(), newLeftmostPlayer, newLeftmostPlayer,
+			nil); err == nil {
+			t.Errorf("expected error running %+v, got none", s)
+		}
+	}
+}
@@ -17,7 +17,11 @@ const (
 
 // PlayerStatistics represents the outcome of a player from a tournament.
 // Points are handed out based on common soccer rules, i.e. 3 points for a win,
-// 1 point for a tie, and 0 points for a loss.
+// 1 point for a tie, and 0 points for a loss. The Elo rating is updated after
+// every game played. Errors is the number of games the player forfeited, e.g.
+// by panicking or playing an illegal move, whether they were scored or not.
+// The Buchholz score is the sum of the points of the player's opponents in all
+// games scored, and breaks ties in the standings.
 type PlayerStatistics struct {
 	PlayerName string
 	Played     int
@@ -25,45 +29,66 @@ type PlayerStatistics struct {
 	Lost       int
 	Tied       int
 	Points     int
+	Buchholz   int
+	Errors     int
+	Rating     float64
 }
 
-// Apply cumulates the delta statistics to the receiver statistics.
+// Apply cumulates the delta statistics to the receiver statistics, except for
+// the Buchholz score and the rating, which are not cumulative.
 func (p *PlayerStatistics) Apply(deltaStatistics *PlayerStatistics) {
 	p.Played += deltaStatistics.Played
 	p.Won += deltaStatistics.Won
 	p.Lost += deltaStatistics.Lost
 	p.Tied += deltaStatistics.Tied
 	p.Points += deltaStatistics.Points
+	p.Errors += deltaStatistics.Errors
 }
 
 // Result is the outcome of a tournament.
-type Result []PlayerStatistics
+type Result struct {
+	Standings Standings
+	// Matches are the games played, if the tournament was set up to keep
+	// their records.
+	Matches []Match
+}
+
+func (r *Result) String() string {
+	return r.Standings.String()
+}
 
-func (t Result) Len() int      { return len(t) }
-func (t Result) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
-func (t Result) Less(i, j int) bool {
-	if t[i].Points == t[j].Points {
-		if t[i].Won == t[j].Won {
-			return t[i].Tied < t[j].Tied
-		}
+// Standings are the statistics of all players of a tournament.
+type Standings []PlayerStatistics
+
+func (t Standings) Len() int      { return len(t) }
+func (t Standings) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+func (t Standings) Less(i, j int) bool {
+	if t[i].Points != t[j].Points {
+		return t[i].Points < t[j].Points
+	}
+	if t[i].Buchholz != t[j].Buchholz {
+		return t[i].Buchholz < t[j].Buchholz
+	}
+	if t[i].Won != t[j].Won {
 		return t[i].Won < t[j].Won
 	}
-	return t[i].Points < t[j].Points
+	return t[i].Tied < t[j].Tied
 }
 
-func (t Result) String() string {
-	const headFormat = "%8s\t%-16s\t%8s\t%8s\t%8s\t%8s\t%8s\n"
-	const rowFormat = "%8d\t%-16s\t%8d\t%8d\t%8d\t%8d\t%8d\n"
+func (t Standings) String() string {
+	const headFormat = "%8s\t%-16s\t%8s\t%8s\t%8s\t%8s\t%8s\t%8s\t%8s\t%8s\n"
+	const rowFormat = "%8d\t%-16s\t%8d\t%8d\t%8d\t%8d\t%8d\t%8d\t%8d\t%8.0f\n"
 	var sep16 = strings.Repeat("-", 16)
 	var sep8 = strings.Repeat("-", 8)
 	sort.Sort(sort.Reverse(t))
 	buf := bytes.NewBufferString("")
 	tw := new(tabwriter.Writer).Init(buf, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, headFormat, "Rank", "Player", "Points", "Games", "Won", "Lost", "Tied")
-	fmt.Fprintf(tw, headFormat, sep8, sep16, sep8, sep8, sep8, sep8, sep8)
+	fmt.Fprintf(tw, headFormat, "Rank", "Player", "Points", "Buchholz", "Games", "Won", "Lost",
+		"Tied", "Errors", "Elo")
+	fmt.Fprintf(tw, headFormat, sep8, sep16, sep8, sep8, sep8, sep8, sep8, sep8, sep8, sep8)
 	for rank, stats := range t {
-		fmt.Fprintf(tw, rowFormat, rank+1, stats.PlayerName, stats.Points, stats.Played, stats.Won,
-			stats.Lost, stats.Tied)
+		fmt.Fprintf(tw, rowFormat, rank+1, stats.PlayerName, stats.Points, stats.Buchholz,
+			stats.Played, stats.Won, stats.Lost, stats.Tied, stats.Errors, stats.Rating)
 	}
 	tw.Flush()
 	return buf.String()
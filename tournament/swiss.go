@@ -0,0 +1,184 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// pairingBudget is the number of steps the search for pairings without
+// repeats may take in a round, before it gives up and repeats are allowed.
+// The search backtracks, which takes exponential time in the number of players
+// once most of them met before.
+const pairingBudget = 10000
+
+// swiss holds the state of a Swiss-system tournament between the rounds.
+type swiss struct {
+	players map[string]PlayerSpawnFunc
+	// met contains the pairs of players that were paired up before.
+	met map[[2]string]bool
+	// colors is the number of games a player moved first minus the number
+	// of games it moved second.
+	colors map[string]int
+	// byes contains the players that got a bye before.
+	byes map[string]bool
+	// steps is the number of steps taken by the search for pairings in the
+	// current attempt.
+	steps int
+}
+
+// PlaySwiss plays the given number of rounds according to the Swiss system and
+// returns the resulting tournament statistics. In every round, players with
+// similar points are paired up for a single game, with the player that moved
+// first less often moving first. Players are paired up with each other at most
+// once, unless the earlier rounds leave no other way to pair them up, which
+// becomes likely with as many rounds as opponents, or no such pairing is found
+// quickly enough. If the number of players is odd, the lowest-ranked player
+// without a bye so far gets a bye worth the points of a win. Ties in the
+// standings are broken by the Buchholz score. If less than two players have
+// been added to the tournament, an error is returned; an aborted tournament
+// and a done ctx are handled like in PlayContext.
+func (t *Tournament) PlaySwiss(ctx context.Context, rounds int) (*Result, error) {
+	if len(t.players) < 2 {
+		return nil, errors.New("unable to play a tournament with less than two players")
+	}
+	s := swiss{
+		players: t.players,
+		met:     make(map[[2]string]bool),
+		colors:  make(map[string]int, len(t.players)),
+		byes:    make(map[string]bool),
+	}
+	sb := t.newScoreboard()
+	sb.total = rounds * (len(t.players) / 2)
+	for r := 0; r < rounds; r++ {
+		pairings, bye := s.pairUp(sb.result().Standings)
+		if bye != "" {
+			sb.bye(bye)
+		}
+		if _, err := t.playGames(ctx, pairings, sb); err != nil {
+			return sb.result(), err
+		}
+	}
+	return sb.result(), nil
+}
+
+// pairUp pairs up the players for the next round based on their standings,
+// and returns the pairings and the name of the player getting a bye, if any.
+func (s *swiss) pairUp(standings Standings) ([]Pairing, string) {
+	sort.Slice(standings, func(i, j int) bool {
+		return standings[i].PlayerName < standings[j].PlayerName
+	})
+	sort.Stable(sort.Reverse(standings))
+	names := make([]string, len(standings))
+	for i, stats := range standings {
+		names[i] = stats.PlayerName
+	}
+	var pairs [][2]string
+	var bye string
+	for _, allowRepeats := range []bool{s.exhausted(names), true} {
+		s.steps = 0
+		if len(names)%2 == 0 {
+			pairs = s.match(names, allowRepeats)
+		} else {
+			pairs, bye = s.matchWithBye(names, allowRepeats)
+		}
+		if pairs != nil {
+			break
+		}
+	}
+	if bye != "" {
+		s.byes[bye] = true
+	}
+	pairings := make([]Pairing, 0, len(pairs))
+	for _, pair := range pairs {
+		one, two := pair[0], pair[1]
+		if s.colors[two] < s.colors[one] {
+			one, two = two, one
+		}
+		s.colors[one]++
+		s.colors[two]--
+		s.met[[2]string{one, two}] = true
+		s.met[[2]string{two, one}] = true
+		pairings = append(pairings, Pairing{
+			Player{one, s.players[one]},
+			Player{two, s.players[two]},
+		})
+	}
+	return pairings, bye
+}
+
+// exhausted returns true if a player met all other players before, in which
+// case pairings must be repeated.
+func (s *swiss) exhausted(names []string) bool {
+	for _, one := range names {
+		opponents := 0
+		for _, two := range names {
+			if s.met[[2]string{one, two}] {
+				opponents++
+			}
+		}
+		if opponents == len(names)-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWithBye matches up all players but the lowest-ranked one that didn't
+// get a bye yet, and returns the matching and the player getting the bye. Only
+// if there is no such matching, a player gets a bye for the second time.
+func (s *swiss) matchWithBye(names []string, allowRepeats bool) ([][2]string, string) {
+	for _, repeatBye := range []bool{false, true} {
+		for i := len(names) - 1; i >= 0; i-- {
+			if s.byes[names[i]] && !repeatBye {
+				continue
+			}
+			rest := make([]string, 0, len(names)-1)
+			rest = append(rest, names[:i]...)
+			rest = append(rest, names[i+1:]...)
+			if pairs := s.match(rest, allowRepeats); pairs != nil {
+				return pairs, names[i]
+			}
+		}
+	}
+	return nil, ""
+}
+
+// match pairs up the ranked players, the highest-ranked player with the
+// next-ranked player it didn't meet yet, backtracking if the remaining players
+// can't be paired up. If repeats are allowed, players that met before are
+// paired up only if they are the only ones left. Nil is returned if there is
+// no such matching, or if it wasn't found within the pairingBudget.
+func (s *swiss) match(names []string, allowRepeats bool) [][2]string {
+	s.steps++
+	if !allowRepeats && s.steps > pairingBudget {
+		return nil
+	}
+	if len(names) == 0 {
+		return [][2]string{}
+	}
+	first := names[0]
+	candidates := make([]int, 0, len(names)-1)
+	for i := 1; i < len(names); i++ {
+		if !s.met[[2]string{first, names[i]}] {
+			candidates = append(candidates, i)
+		}
+	}
+	if allowRepeats {
+		// players met before are only considered after all others
+		for i := 1; i < len(names); i++ {
+			if s.met[[2]string{first, names[i]}] {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+	for _, i := range candidates {
+		rest := make([]string, 0, len(names)-2)
+		rest = append(rest, names[1:i]...)
+		rest = append(rest, names[i+1:]...)
+		if pairs := s.match(rest, allowRepeats); pairs != nil {
+			return append([][2]string{{first, names[i]}}, pairs...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package tournament
+
+import (
+	"4iar/board"
+	"4iar/player"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestPlaySwiss(t *testing.T) {
+	for _, players := range []int{8, 7} {
+		rounds := players / 2
+		tm := NewTournament()
+		tm.KeepRecords = true
+		for i := 0; i < players; i++ {
+			tm.AddPlayer(fmt.Sprintf("Random %d", i), player.NewRandomPlayer)
+		}
+		result, err := tm.PlaySwiss(context.Background(), rounds)
+		if err != nil {
+			t.Fatalf("play tournament: %v", err)
+		}
+		if games := rounds * (players / 2); len(result.Matches) != games {
+			t.Errorf("expected %d matches, got %d", games, len(result.Matches))
+		}
+		met := make(map[[2]string]bool)
+		colors := make(map[string]int)
+		for _, match := range result.Matches {
+			one, two := match.PlayerOneName, match.PlayerTwoName
+			if met[[2]string{one, two}] {
+				t.Errorf("expected %s and %s to be paired up once", one, two)
+			}
+			met[[2]string{one, two}] = true
+			met[[2]string{two, one}] = true
+			colors[one]++
+			colors[two]--
+		}
+		for _, stats := range result.Standings {
+			if stats.Played != rounds-players%2 && stats.Played != rounds {
+				t.Errorf("expected %s to play %d games, played %d", stats.PlayerName,
+					rounds, stats.Played)
+			}
+			if c := colors[stats.PlayerName]; c < -2 || c > 2 {
+				t.Errorf("expected %s to move first about as often as second, got %d",
+					stats.PlayerName, c)
+			}
+		}
+	}
+}
+
+func TestPlaySwissBuchholz(t *testing.T) {
+	tm := NewTournament()
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Winning", player.NewWinningMovePlayer)
+	tm.AddPlayer("Greedy", newGreedyPlayer)
+	tm.AddPlayer("Minimax", func(f board.Field, seed int64) *player.Player {
+		return player.NewMinimaxPlayer(f, 2, nil)
+	})
+	result, err := tm.PlaySwiss(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	points := make(map[string]int)
+	for _, stats := range result.Standings {
+		points[stats.PlayerName] = stats.Points
+	}
+	for _, stats := range result.Standings {
+		// every player met every other player once
+		buchholz := -stats.Points
+		for _, p := range points {
+			buchholz += p
+		}
+		if stats.Buchholz != buchholz {
+			t.Errorf("expected Buchholz score %d for %s, got %d", buchholz, stats.PlayerName,
+				stats.Buchholz)
+		}
+	}
+}
+
+func TestPlaySwissManyPlayers(t *testing.T) {
+	// pairing up many players becomes hard once most of them met before, so
+	// the search for pairings without repeats must give up in time
+	const players = 40
+	tm := NewTournament()
+	tm.KeepRecords = true
+	for i := 0; i < players; i++ {
+		tm.AddPlayer(fmt.Sprintf("Random %02d", i), player.NewRandomPlayer)
+	}
+	for _, rounds := range []int{players / 2, players - 1} {
+		result, err := tm.PlaySwiss(context.Background(), rounds)
+		if err != nil {
+			t.Fatalf("play tournament: %v", err)
+		}
+		for _, stats := range result.Standings {
+			if stats.Played != rounds {
+				t.Errorf("expected %s to play %d games in %d rounds, played %d",
+					stats.PlayerName, rounds, rounds, stats.Played)
+			}
+		}
+		met := make(map[[2]string]bool)
+		repeats := 0
+		for _, match := range result.Matches {
+			one, two := match.PlayerOneName, match.PlayerTwoName
+			if met[[2]string{one, two}] {
+				repeats++
+			}
+			met[[2]string{one, two}] = true
+			met[[2]string{two, one}] = true
+		}
+		// with half as many rounds as opponents, few pairings may be repeated
+		if games := rounds * players / 2; rounds == players/2 && repeats > games/10 {
+			t.Errorf("expected at most %d repeated pairings in %d rounds, got %d", games/10,
+				rounds, repeats)
+		}
+	}
+}
@@ -6,24 +6,115 @@ import (
 	"4iar/board"
 	"4iar/game"
 	"4iar/player"
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // PlayerSpawnFunc is a function that creates a new player with the given
-// field.
-type PlayerSpawnFunc func(board.Field) *player.Player
+// field, which makes its random choices, if any, based on the given seed.
+type PlayerSpawnFunc func(field board.Field, seed int64) *player.Player
+
+// ErrorPolicy determines how games are scored that a player lost by forfeit,
+// e.g. by panicking or playing an illegal move, or that failed altogether.
+type ErrorPolicy int
+
+const (
+	// CountAsLoss scores a forfeited game as lost by the forfeiting player.
+	CountAsLoss ErrorPolicy = iota
+	// Void doesn't score a forfeited game at all.
+	Void
+	// Abort stops the tournament on the first forfeited game.
+	Abort
+)
+
+var errorPolicyNames = map[ErrorPolicy]string{
+	CountAsLoss: "loss",
+	Void:        "void",
+	Abort:       "abort",
+}
+
+func (p ErrorPolicy) String() string {
+	if name, ok := errorPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("policy(%d)", int(p))
+}
+
+// ParseErrorPolicy returns the error policy with the given name, i.e. "loss",
+// "void", or "abort".
+func ParseErrorPolicy(name string) (ErrorPolicy, error) {
+	for policy, policyName := range errorPolicyNames {
+		if policyName == name {
+			return policy, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown error policy '%s'", name)
+}
+
+// ErrorAborted indicates that a tournament was aborted due to a forfeited or
+// failed game.
+var ErrorAborted = errors.New("tournament aborted")
 
 // Tournament is a set of named players, which are created using their
 // PlayerSpawnFunc.
-type Tournament map[string]PlayerSpawnFunc
+type Tournament struct {
+	players map[string]PlayerSpawnFunc
+
+	// KeepRecords makes Play keep the records of all games played in the
+	// result's matches.
+	KeepRecords bool
+	// KFactor is the maximum change of a player's Elo rating by a single game
+	// (DefaultKFactor, if zero).
+	KFactor float64
+	// InitialRating is the Elo rating every player starts with
+	// (DefaultInitialRating, if zero).
+	InitialRating float64
+	// MoveTime is the time a player has to pick a single move; unlimited, if
+	// zero.
+	MoveTime time.Duration
+	// GameTime is the time a player has to pick all moves of a game;
+	// unlimited, if zero.
+	GameTime time.Duration
+	// ErrorPolicy determines how forfeited and failed games are scored
+	// (CountAsLoss, if zero).
+	ErrorPolicy ErrorPolicy
+	// Concurrency is the maximum number of games played at the same time
+	// (the number of CPUs, if zero).
+	Concurrency int
+	// Progress is called after every game played, if not nil.
+	Progress func(Progress)
+	// Seed is the master seed, from which the seeds of the players of every
+	// game are derived, so that a tournament can be replayed exactly, as long
+	// as the players don't depend on timing.
+	Seed int64
+}
+
+// Progress is the state of a tournament being played.
+type Progress struct {
+	// Done is the number of games played so far.
+	Done int
+	// Total is the number of games to be played, or zero, if it isn't known
+	// in advance, e.g. for a knockout tournament.
+	Total int
+	// Elapsed is the time since the tournament started.
+	Elapsed time.Duration
+	// ETA is the estimated time until the tournament is finished, or zero,
+	// if the total number of games isn't known.
+	ETA time.Duration
+	// Standings are the statistics of all players so far, ranked from first
+	// to last.
+	Standings Standings
+}
 
 // NewTournament creates a new, empty tournament, i.e. without players.
 func NewTournament() *Tournament {
-	t := Tournament(make(map[string]PlayerSpawnFunc, 0))
+	t := Tournament{players: make(map[string]PlayerSpawnFunc, 0)}
 	return &t
 }
 
@@ -36,10 +127,10 @@ func (t *Tournament) AddPlayer(name string, spawnFunc PlayerSpawnFunc) error {
 	if spawnFunc == nil {
 		return errors.New("spawnFunc must not be nil")
 	}
-	if _, ok := (*t)[name]; ok {
+	if _, ok := t.players[name]; ok {
 		return fmt.Errorf("a player with name='%s' was added before", name)
 	}
-	(*t)[name] = spawnFunc
+	t.players[name] = spawnFunc
 	return nil
 }
 
@@ -50,108 +141,146 @@ type Player struct {
 	SpawnFunc PlayerSpawnFunc
 }
 
-// Pairing is match pairing of two created players and their names.
+// Pairing is a match pairing of two players, with player one moving first.
+// The players are spawned for every game played, so that no player instance is
+// used by more than one game.
 type Pairing struct {
-	PlayerOne     *player.Player
+	PlayerOne Player
+	PlayerTwo Player
+}
+
+// Match is a game played in a tournament between two named players.
+type Match struct {
 	PlayerOneName string
-	PlayerTwo     *player.Player
 	PlayerTwoName string
+	Record        *game.Record
+	// Err is the error that made the game fail, if any.
+	Err error
 }
 
 // Play plays the given number of rounds and returns the resulting tournament
 // statistics. Every player is paired up twice with each other player of the
 // tournament in flipped order to compensate for a possible first-mover
 // advantage. If less than two players have been added to the tournament, an
-// error is returned.
-func (t *Tournament) Play(rounds int) (Result, error) {
-	if len(*t) < 2 {
+// error is returned. If the tournament is aborted according to its
+// ErrorPolicy, the statistics of the games scored so far are returned along
+// with an error wrapping ErrorAborted.
+func (t *Tournament) Play(rounds int) (*Result, error) {
+	return t.PlayContext(context.Background(), rounds)
+}
+
+// PlayContext is like Play, but stops playing once ctx is done, returning the
+// statistics of the games scored so far along with ctx.Err().
+func (t *Tournament) PlayContext(ctx context.Context, rounds int) (*Result, error) {
+	if len(t.players) < 2 {
 		return nil, errors.New("unable to play a tournament with less than two players")
 	}
 	pairings := pairUp(t)
-	stats := make(map[string]*PlayerStatistics, 0)
-	for name := range *t {
-		ps := PlayerStatistics{name, 0, 0, 0, 0, 0}
-		stats[name] = &ps
+	games := make([]Pairing, 0, rounds*len(pairings))
+	for r := 0; r < rounds; r++ {
+		games = append(games, pairings...)
+	}
+	sb := t.newScoreboard()
+	sb.total = len(games)
+	if _, err := t.playGames(ctx, games, sb); err != nil {
+		return sb.result(), err
+	}
+	return sb.result(), nil
+}
+
+// playGames plays the games of all pairings, with up to Concurrency games at
+// the same time, scores them on the scoreboard in the order of the pairings
+// and returns them. The players of every game are spawned with seeds derived
+// from the tournament's seed and the number of the game within the
+// tournament. An error is returned if the scoreboard aborts the tournament, in
+// which case the games still running are cancelled, or if ctx is done, in
+// which case the games not started yet aren't played.
+func (t *Tournament) playGames(ctx context.Context, pairings []Pairing,
+	sb *scoreboard) ([]Match, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	concurrency := t.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	first := sb.scheduled
+	sb.scheduled += len(pairings)
+	indices := make(chan int)
+	go func() {
+		defer close(indices)
+		for i := range pairings {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	type result struct {
+		index int
+		match Match
 	}
 	var wg sync.WaitGroup
-	deltaStatChan := make(chan PlayerStatistics)
-	for r := 0; r < rounds; r++ {
-		for _, pairing := range pairings {
-			one := pairing.PlayerOne
-			two := pairing.PlayerTwo
-			oneName := pairing.PlayerOneName
-			twoName := pairing.PlayerTwoName
-			g := game.NewGame(one, two)
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				outcome, err := g.Play(false)
-				if err != nil {
-					log.Print(err)
+	results := make(chan result)
+	for w := 0; w < concurrency && w < len(pairings); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				one, two := pairings[i].PlayerOne, pairings[i].PlayerTwo
+				number := first + i
+				g := game.NewGame(
+					one.SpawnFunc(board.PlayerOne, player.DeriveSeed(t.Seed, 2*number)),
+					two.SpawnFunc(board.PlayerTwo, player.DeriveSeed(t.Seed, 2*number+1)))
+				g.MoveTime = t.MoveTime
+				g.GameTime = t.GameTime
+				record, err := g.PlayContext(ctx, false)
+				select {
+				case results <- result{i, Match{one.Name, two.Name, record, err}}:
+				case <-ctx.Done():
 					return
 				}
-				deltaStatOne := PlayerStatistics{oneName, 1, 0, 0, 0, 0}
-				deltaStatTwo := PlayerStatistics{twoName, 1, 0, 0, 0, 0}
-				if outcome == board.PlayerOneWins {
-					deltaStatOne.Won = 1
-					deltaStatOne.Points = WinPoints
-					deltaStatTwo.Lost = 1
-				} else if outcome == board.PlayerTwoWins {
-					deltaStatOne.Lost = 1
-					deltaStatTwo.Won = 1
-					deltaStatTwo.Points = WinPoints
-				} else if outcome == board.Tie {
-					deltaStatOne.Tied = 1
-					deltaStatOne.Points = TiePoints
-					deltaStatTwo.Tied = 1
-					deltaStatTwo.Points = TiePoints
-				}
-				deltaStatChan <- deltaStatOne
-				deltaStatChan <- deltaStatTwo
-			}()
-		}
+			}
+		}()
 	}
 	go func() {
 		wg.Wait()
-		close(deltaStatChan)
+		close(results)
 	}()
-	for deltaStat := range deltaStatChan {
-		name := deltaStat.PlayerName
-		if _, ok := stats[name]; !ok {
-			log.Printf("no stats found for %s", deltaStat.PlayerName)
-			continue
+	// score the matches in order, so that the ratings don't depend on timing
+	pending := make(map[int]Match, concurrency)
+	matches := make([]Match, 0, len(pairings))
+	for r := range results {
+		pending[r.index] = r.match
+		for {
+			match, ok := pending[len(matches)]
+			if !ok {
+				break
+			}
+			delete(pending, len(matches))
+			if err := sb.add(match); err != nil {
+				return matches, err
+			}
+			matches = append(matches, match)
+			sb.report()
 		}
-		stats[name].Apply(&deltaStat)
-	}
-	result := make([]PlayerStatistics, 0)
-	for _, stat := range stats {
-		result = append(result, *stat)
 	}
-	return Result(result), nil
+	return matches, ctx.Err()
 }
 
 func pairUp(t *Tournament) []Pairing {
 	pairings := make([]Pairing, 0)
 	players := make([]Player, 0)
-	for name, spawnFunc := range *t {
+	for name, spawnFunc := range t.players {
 		players = append(players, Player{name, spawnFunc})
 	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].Name < players[j].Name
+	})
 	for i, leftPlayer := range players {
 		for _, rightPlayer := range players[i+1:] {
-			match := Pairing{
-				leftPlayer.SpawnFunc(board.PlayerOne),
-				leftPlayer.Name,
-				rightPlayer.SpawnFunc(board.PlayerTwo),
-				rightPlayer.Name,
-			}
-			rematch := Pairing{
-				rightPlayer.SpawnFunc(board.PlayerOne),
-				rightPlayer.Name,
-				leftPlayer.SpawnFunc(board.PlayerTwo),
-				leftPlayer.Name,
-			}
-			pairings = append(pairings, match)
-			pairings = append(pairings, rematch)
+			pairings = append(pairings, Pairing{leftPlayer, rightPlayer})
+			pairings = append(pairings, Pairing{rightPlayer, leftPlayer})
 		}
 	}
 	return pairings
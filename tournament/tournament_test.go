@@ -0,0 +1,320 @@
+package tournament
+
+import (
+	"4iar/board"
+	"4iar/game"
+	"4iar/player"
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newGreedyPlayer(field board.Field, seed int64) *player.Player {
+	return player.NewGreedyPlayer(field)
+}
+
+// panickingPlayer panics instead of playing a move.
+type panickingPlayer struct {
+	field board.Field
+}
+
+func newPanickingPlayer(field board.Field, seed int64) *player.Player {
+	p := player.Player(&panickingPlayer{field})
+	return &p
+}
+
+func (p *panickingPlayer) Play(b *board.Board) *board.Move {
+	panic("out of cheese")
+}
+
+func (p *panickingPlayer) Field() board.Field {
+	return p.field
+}
+
+func TestPlay(t *testing.T) {
+	const rounds = 5
+	tm := NewTournament()
+	tm.KeepRecords = true
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Winning", player.NewWinningMovePlayer)
+	tm.AddPlayer("Greedy", newGreedyPlayer)
+	result, err := tm.Play(rounds)
+	if err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	// every player plays a match and a rematch against both other players
+	games := len(tm.players) * (len(tm.players) - 1) * rounds
+	if len(result.Matches) != games {
+		t.Errorf("expected %d matches, got %d", games, len(result.Matches))
+	}
+	var played int
+	var ratings float64
+	for _, stats := range result.Standings {
+		if stats.Won+stats.Lost+stats.Tied != stats.Played {
+			t.Errorf("expected won, lost and tied games of %s to add up to %d",
+				stats.PlayerName, stats.Played)
+		}
+		played += stats.Played
+		ratings += stats.Rating
+	}
+	if played != 2*games {
+		t.Errorf("expected %d games played by all players, got %d", 2*games, played)
+	}
+	if expected := float64(len(tm.players) * DefaultInitialRating); math.Abs(ratings-expected) > 1e-6 {
+		t.Errorf("expected ratings to sum up to %.0f, got %.2f", expected, ratings)
+	}
+}
+
+func TestPlayForfeits(t *testing.T) {
+	const rounds = 3
+	tm := NewTournament()
+	tm.KeepRecords = true
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Panicking", newPanickingPlayer)
+	result, err := tm.Play(rounds)
+	if err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	if len(result.Matches) != 2*rounds {
+		t.Errorf("expected %d matches, got %d", 2*rounds, len(result.Matches))
+	}
+	for _, match := range result.Matches {
+		if match.Record.Termination != game.Panic {
+			t.Errorf("expected termination by %v, got %v", game.Panic, match.Record.Termination)
+		}
+	}
+	for _, stats := range result.Standings {
+		if stats.PlayerName == "Panicking" && (stats.Lost != 2*rounds || stats.Errors != 2*rounds) {
+			t.Errorf("expected %s to lose all %d games by error, lost %d with %d errors",
+				stats.PlayerName, 2*rounds, stats.Lost, stats.Errors)
+		}
+	}
+}
+
+func TestPlayForfeitsVoid(t *testing.T) {
+	const rounds = 3
+	tm := NewTournament()
+	tm.ErrorPolicy = Void
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Panicking", newPanickingPlayer)
+	result, err := tm.Play(rounds)
+	if err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	for _, stats := range result.Standings {
+		if stats.Played != 0 || stats.Rating != DefaultInitialRating {
+			t.Errorf("expected %s to play no scored games, played %d with rating %.0f",
+				stats.PlayerName, stats.Played, stats.Rating)
+		}
+		if stats.PlayerName == "Panicking" && stats.Errors != 2*rounds {
+			t.Errorf("expected %s to have %d errors, got %d", stats.PlayerName, 2*rounds,
+				stats.Errors)
+		}
+	}
+}
+
+func TestPlayForfeitsAbort(t *testing.T) {
+	tm := NewTournament()
+	tm.ErrorPolicy = Abort
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Panicking", newPanickingPlayer)
+	result, err := tm.Play(3)
+	if !errors.Is(err, ErrorAborted) {
+		t.Fatalf("expected error %v, got %v", ErrorAborted, err)
+	}
+	if result == nil {
+		t.Fatal("expected partial result, got none")
+	}
+}
+
+func TestParseErrorPolicy(t *testing.T) {
+	for _, policy := range []ErrorPolicy{CountAsLoss, Void, Abort} {
+		parsed, err := ParseErrorPolicy(policy.String())
+		if err != nil || parsed != policy {
+			t.Errorf("expected %v to parse as itself, got %v (%v)", policy, parsed, err)
+		}
+	}
+	if _, err := ParseErrorPolicy("ignore"); err == nil {
+		t.Error("expected error parsing unknown policy, got none")
+	}
+}
+
+// countingPlayer plays the leftmost valid move after a short delay, counting
+// the number of players picking a move at the same time.
+type countingPlayer struct {
+	field  board.Field
+	active *int32
+	max    *int32
+}
+
+func (p *countingPlayer) Play(b *board.Board) *board.Move {
+	active := atomic.AddInt32(p.active, 1)
+	defer atomic.AddInt32(p.active, -1)
+	for {
+		max := atomic.LoadInt32(p.max)
+		if active <= max || atomic.CompareAndSwapInt32(p.max, max, active) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *countingPlayer) Field() board.Field {
+	return p.field
+}
+
+func TestPlayConcurrency(t *testing.T) {
+	const concurrency = 2
+	var active, max int32
+	spawn := func(f board.Field, seed int64) *player.Player {
+		p := player.Player(&countingPlayer{f, &active, &max})
+		return &p
+	}
+	tm := NewTournament()
+	tm.Concurrency = concurrency
+	tm.AddPlayer("One", spawn)
+	tm.AddPlayer("Two", spawn)
+	tm.AddPlayer("Three", spawn)
+	var progress []Progress
+	tm.Progress = func(p Progress) {
+		progress = append(progress, p)
+	}
+	if _, err := tm.Play(2); err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	if max > concurrency {
+		t.Errorf("expected at most %d games at the same time, got %d", concurrency, max)
+	}
+	const games = 12
+	if len(progress) != games {
+		t.Fatalf("expected progress after %d games, got %d", games, len(progress))
+	}
+	for i, p := range progress {
+		if p.Done != i+1 || p.Total != games || len(p.Standings) != len(tm.players) {
+			t.Errorf("expected %d of %d games done, got %+v", i+1, games, p)
+		}
+	}
+	if last := progress[games-1]; last.ETA != 0 {
+		t.Errorf("expected no time left after the last game, got %v", last.ETA)
+	}
+}
+
+func TestPlayContextCancelled(t *testing.T) {
+	const cancelAfter = 3
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tm := NewTournament()
+	tm.Concurrency = 1
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	tm.AddPlayer("Winning", player.NewWinningMovePlayer)
+	tm.Progress = func(p Progress) {
+		if p.Done == cancelAfter {
+			cancel()
+		}
+	}
+	result, err := tm.PlayContext(ctx, 100)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error %v, got %v", context.Canceled, err)
+	}
+	var played int
+	for _, stats := range result.Standings {
+		played += stats.Played
+	}
+	if played < 2*cancelAfter || played > 2*(cancelAfter+1) {
+		t.Errorf("expected about %d games played by all players, got %d", 2*cancelAfter, played)
+	}
+}
+
+// statefulPlayer plays the leftmost valid move, remembering the positions it
+// has seen without synchronization, and counts being used for more than one
+// game.
+type statefulPlayer struct {
+	field  board.Field
+	plies  int
+	seen   map[uint64]int
+	reused *int32
+}
+
+func (p *statefulPlayer) Play(b *board.Board) *board.Move {
+	if b.Plies() < p.plies {
+		atomic.AddInt32(p.reused, 1)
+	}
+	p.plies = b.Plies()
+	p.seen[b.Hash()]++
+	move := b.ValidMoves()[0]
+	return &move
+}
+
+func (p *statefulPlayer) Field() board.Field {
+	return p.field
+}
+
+// TestPlayStatefulPlayers is best run with the race detector.
+func TestPlayStatefulPlayers(t *testing.T) {
+	var reused int32
+	spawn := func(f board.Field, seed int64) *player.Player {
+		p := player.Player(&statefulPlayer{f, 0, make(map[uint64]int), &reused})
+		return &p
+	}
+	tm := NewTournament()
+	tm.Concurrency = 4
+	tm.AddPlayer("One", spawn)
+	tm.AddPlayer("Two", spawn)
+	tm.AddPlayer("Three", spawn)
+	if _, err := tm.Play(10); err != nil {
+		t.Fatalf("play tournament: %v", err)
+	}
+	if reused > 0 {
+		t.Errorf("expected a player instance per game, got %d reused instances", reused)
+	}
+}
+
+func TestPlaySeed(t *testing.T) {
+	play := func(seed int64) *Result {
+		tm := NewTournament()
+		tm.KeepRecords = true
+		tm.Concurrency = 4
+		tm.Seed = seed
+		tm.AddPlayer("Random", player.NewRandomPlayer)
+		tm.AddPlayer("Winning", player.NewWinningMovePlayer)
+		tm.AddPlayer("Greedy", newGreedyPlayer)
+		result, err := tm.Play(5)
+		if err != nil {
+			t.Fatalf("play tournament: %v", err)
+		}
+		sort.Slice(result.Standings, func(i, j int) bool {
+			return result.Standings[i].PlayerName < result.Standings[j].PlayerName
+		})
+		return result
+	}
+	first, second, other := play(42), play(42), play(43)
+	if !reflect.DeepEqual(first.Standings, second.Standings) {
+		t.Errorf("expected the same standings for the same seed, got\n%v\nand\n%v", first,
+			second)
+	}
+	var differ bool
+	for i, match := range first.Matches {
+		if match.Record.Notation() != second.Matches[i].Record.Notation() {
+			t.Fatalf("expected the same moves in match %d for the same seed", i)
+		}
+		differ = differ || match.Record.Notation() != other.Matches[i].Record.Notation()
+	}
+	if !differ {
+		t.Error("expected different moves for a different seed")
+	}
+}
+
+func TestPlayTooFewPlayers(t *testing.T) {
+	tm := NewTournament()
+	tm.AddPlayer("Random", player.NewRandomPlayer)
+	if _, err := tm.Play(1); err == nil {
+		t.Error("expected error playing with a single player, got none")
+	}
+}